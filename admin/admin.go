@@ -0,0 +1,63 @@
+// Package admin provides the HTTP listener operators use to manage a
+// running portfolio server.
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Arpan-206/terminal-portfolio/feed"
+	"github.com/Arpan-206/terminal-portfolio/recorder"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+)
+
+// NewHandler returns the admin routes:
+//
+//	GET  /recording                 report whether sessions are recorded
+//	POST /recording?enabled=<bool>  turn session recording on or off
+//	GET  /rss.xml, /atom.xml        feeds of posts, when feeds is not nil
+func NewHandler(rec *recorder.Recorder, feeds *feed.Options, posts []tui.BlogPost) http.Handler {
+	mux := http.NewServeMux()
+	if feeds != nil {
+		mux.HandleFunc("GET /rss.xml", serveFeed(feed.WriteRSS, "application/rss+xml", *feeds, posts))
+		mux.HandleFunc("GET /atom.xml", serveFeed(feed.WriteAtom, "application/atom+xml", *feeds, posts))
+	}
+	mux.HandleFunc("GET /recording", func(w http.ResponseWriter, r *http.Request) {
+		writeRecordingStatus(w, rec)
+	})
+	mux.HandleFunc("POST /recording", func(w http.ResponseWriter, r *http.Request) {
+		enabled, err := strconv.ParseBool(r.URL.Query().Get("enabled"))
+		if err != nil {
+			http.Error(w, "enabled must be true or false", http.StatusBadRequest)
+			return
+		}
+		rec.SetEnabled(enabled)
+		writeRecordingStatus(w, rec)
+	})
+	return mux
+}
+
+// serveFeed renders a feed of posts once and serves it on every request
+func serveFeed(write func(io.Writer, feed.Options, []tui.BlogPost) error, contentType string, opts feed.Options, posts []tui.BlogPost) http.HandlerFunc {
+	var buf bytes.Buffer
+	err := write(&buf, opts, posts)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType+"; charset=utf-8")
+		w.Write(buf.Bytes())
+	}
+}
+
+func writeRecordingStatus(w http.ResponseWriter, rec *recorder.Recorder) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"enabled": rec.Enabled(),
+		"dir":     rec.Dir(),
+	})
+}
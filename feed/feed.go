@@ -0,0 +1,275 @@
+// Package feed generates RSS 2.0 and Atom 1.0 feeds from published blog
+// posts. Dates come from post frontmatter so regenerating a feed never
+// changes it unless the posts do.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Arpan-206/terminal-portfolio/site"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+)
+
+// Options describes the site a feed belongs to
+type Options struct {
+	// SiteURL is the absolute URL of the static site, such as
+	// "https://example.com/". Post links follow the site's permalinks.
+	SiteURL string
+
+	Title       string
+	Description string
+}
+
+func (o Options) withDefaults() Options {
+	if !strings.HasSuffix(o.SiteURL, "/") {
+		o.SiteURL += "/"
+	}
+	if o.Title == "" {
+		o.Title = "Arpan's Blog"
+	}
+	if o.Description == "" {
+		o.Description = "Posts from Arpan's terminal portfolio"
+	}
+	return o
+}
+
+func (o Options) postURL(post tui.BlogPost) string {
+	return o.SiteURL + "blog/" + post.ID + "/"
+}
+
+// entry is a post prepared for either feed format
+type entry struct {
+	post   tui.BlogPost
+	url    string
+	author string
+	html   string
+}
+
+func entries(opts Options, posts []tui.BlogPost) ([]entry, error) {
+	var out []entry
+	for _, post := range posts {
+		html, err := site.MarkdownToHTML(post.Content)
+		if err != nil {
+			return nil, fmt.Errorf("rendering post %s: %w", post.ID, err)
+		}
+
+		author := post.Author
+		if author == "" {
+			author = tui.GetProfile().Name
+		}
+
+		out = append(out, entry{post: post, url: opts.postURL(post), author: author, html: html})
+	}
+	return out, nil
+}
+
+// updated returns the date of the newest post, which posts are ordered by
+func updated(posts []tui.BlogPost) time.Time {
+	var newest time.Time
+	for _, post := range posts {
+		if post.Date.After(newest) {
+			newest = post.Date
+		}
+	}
+	return newest
+}
+
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	ContentNS string     `xml:"xmlns:content,attr"`
+	DCNS      string     `xml:"xmlns:dc,attr"`
+	AtomNS    string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      atomLink  `xml:"atom:link"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	PubDate     string   `xml:"pubDate"`
+	Description string   `xml:"description"`
+	Categories  []string `xml:"category"`
+	Creator     string   `xml:"dc:creator"`
+	Content     cdata    `xml:"content:encoded"`
+}
+
+type cdata struct {
+	Text string `xml:",cdata"`
+}
+
+// WriteRSS writes an RSS 2.0 feed of posts to w
+func WriteRSS(w io.Writer, opts Options, posts []tui.BlogPost) error {
+	opts = opts.withDefaults()
+	items, err := entries(opts, posts)
+	if err != nil {
+		return err
+	}
+
+	feed := rss{
+		Version:   "2.0",
+		ContentNS: "http://purl.org/rss/1.0/modules/content/",
+		DCNS:      "http://purl.org/dc/elements/1.1/",
+		AtomNS:    "http://www.w3.org/2005/Atom",
+		Channel: rssChannel{
+			Title:       opts.Title,
+			Link:        opts.SiteURL,
+			Description: opts.Description,
+			AtomLink:    atomLink{Href: opts.SiteURL + "rss.xml", Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	if newest := updated(posts); !newest.IsZero() {
+		feed.Channel.LastBuildDate = newest.Format(time.RFC1123Z)
+	}
+
+	for _, e := range items {
+		feed.Channel.Items = append(feed.Channel.Items, rssItem{
+			Title:       e.post.Title,
+			Link:        e.url,
+			GUID:        e.url,
+			PubDate:     e.post.Date.Format(time.RFC1123Z),
+			Description: e.post.Summary,
+			Categories:  e.post.Tags,
+			Creator:     e.author,
+			Content:     cdata{Text: e.html},
+		})
+	}
+
+	return writeXML(w, feed)
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Tagline string      `xml:"subtitle"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomPerson  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Link       atomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     atomPerson     `xml:"author"`
+	Summary    atomText       `xml:"summary"`
+	Content    atomText       `xml:"content"`
+	Categories []atomCategory `xml:"category"`
+}
+
+// WriteAtom writes an Atom 1.0 feed of posts to w
+func WriteAtom(w io.Writer, opts Options, posts []tui.BlogPost) error {
+	opts = opts.withDefaults()
+	items, err := entries(opts, posts)
+	if err != nil {
+		return err
+	}
+
+	feed := atomFeed{
+		ID:      opts.SiteURL,
+		Title:   opts.Title,
+		Tagline: opts.Description,
+		Updated: updated(posts).Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: opts.SiteURL + "atom.xml", Rel: "self", Type: "application/atom+xml"},
+			{Href: opts.SiteURL, Rel: "alternate", Type: "text/html"},
+		},
+		Author: atomPerson{Name: tui.GetProfile().Name},
+	}
+
+	for _, e := range items {
+		date := e.post.Date.Format(time.RFC3339)
+		ae := atomEntry{
+			ID:        e.url,
+			Title:     e.post.Title,
+			Link:      atomLink{Href: e.url, Rel: "alternate", Type: "text/html"},
+			Published: date,
+			Updated:   date,
+			Author:    atomPerson{Name: e.author},
+			Summary:   atomText{Type: "text", Body: e.post.Summary},
+			Content:   atomText{Type: "html", Body: e.html},
+		}
+		for _, tag := range e.post.Tags {
+			ae.Categories = append(ae.Categories, atomCategory{Term: tag})
+		}
+		feed.Entries = append(feed.Entries, ae)
+	}
+
+	return writeXML(w, feed)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteFiles writes rss.xml and atom.xml for posts into dir
+func WriteFiles(dir string, opts Options, posts []tui.BlogPost) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	for name, write := range map[string]func(io.Writer, Options, []tui.BlogPost) error{
+		"rss.xml":  WriteRSS,
+		"atom.xml": WriteAtom,
+	} {
+		f, err := os.Create(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		if err := write(f, opts, posts); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
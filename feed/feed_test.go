@@ -0,0 +1,143 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Arpan-206/terminal-portfolio/tui"
+)
+
+// rssDoc and atomDoc read back the fields the feeds escape
+type rssDoc struct {
+	Title string `xml:"channel>title"`
+	Items []struct {
+		Title       string   `xml:"title"`
+		Description string   `xml:"description"`
+		Categories  []string `xml:"category"`
+		Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+		Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	} `xml:"channel>item"`
+}
+
+type atomDoc struct {
+	Title   string `xml:"title"`
+	Entries []struct {
+		Title      string `xml:"title"`
+		Summary    string `xml:"summary"`
+		Content    string `xml:"content"`
+		Author     string `xml:"author>name"`
+		Categories []struct {
+			Term string `xml:"term,attr"`
+		} `xml:"category"`
+	} `xml:"entry"`
+}
+
+func TestFeedsEscapeText(t *testing.T) {
+	tests := []struct {
+		name        string
+		post        tui.BlogPost
+		wantContent string
+	}{
+		{
+			name: "markup characters",
+			post: tui.BlogPost{
+				Title:   `Generics <T> & "interfaces"`,
+				Summary: "Why a < b && b > c matters",
+				Tags:    []string{"c&c", "<go>"},
+				Author:  "Ada & Bob <ada@example.com>",
+				Content: "Use `<T any>` & friends.",
+			},
+			wantContent: "<code>&lt;T any&gt;</code> &amp; friends",
+		},
+		{
+			name: "CDATA terminator in content",
+			post: tui.BlogPost{
+				Title:   "Ending ]]> early",
+				Summary: "]]>",
+				Author:  "Ada",
+				Content: "```\n]]>\n```\n\n<script>alert(1)</script>",
+			},
+			wantContent: "]]&gt;",
+		},
+		{
+			name: "non-ASCII text",
+			post: tui.BlogPost{
+				Title:   "Café ☕ — naïve",
+				Summary: "日本語",
+				Author:  "Zoë",
+				Content: "Ünïcödé",
+			},
+			wantContent: "Ünïcödé",
+		},
+	}
+
+	opts := Options{SiteURL: "https://example.com", Title: "Notes & <Thoughts>"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.post.ID = "post"
+			tt.post.Date = time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+			posts := []tui.BlogPost{tt.post}
+
+			var rssOut bytes.Buffer
+			if err := WriteRSS(&rssOut, opts, posts); err != nil {
+				t.Fatal(err)
+			}
+			var r rssDoc
+			if err := xml.Unmarshal(rssOut.Bytes(), &r); err != nil {
+				t.Fatalf("RSS is not well-formed: %v\n%s", err, rssOut.String())
+			}
+			if r.Title != opts.Title {
+				t.Errorf("RSS channel title = %q, want %q", r.Title, opts.Title)
+			}
+			if len(r.Items) != 1 {
+				t.Fatalf("RSS has %d items, want 1", len(r.Items))
+			}
+			item := r.Items[0]
+			if item.Title != tt.post.Title || item.Description != tt.post.Summary || item.Creator != tt.post.Author {
+				t.Errorf("RSS item = %q, %q, %q, want %q, %q, %q",
+					item.Title, item.Description, item.Creator, tt.post.Title, tt.post.Summary, tt.post.Author)
+			}
+			if strings.Join(item.Categories, ",") != strings.Join(tt.post.Tags, ",") {
+				t.Errorf("RSS categories = %q, want %q", item.Categories, tt.post.Tags)
+			}
+			if !strings.Contains(item.Content, tt.wantContent) {
+				t.Errorf("RSS content = %q, want it to contain %q", item.Content, tt.wantContent)
+			}
+			if strings.Contains(item.Content, "<script>") {
+				t.Errorf("RSS content kept raw HTML: %q", item.Content)
+			}
+
+			var atomOut bytes.Buffer
+			if err := WriteAtom(&atomOut, opts, posts); err != nil {
+				t.Fatal(err)
+			}
+			var a atomDoc
+			if err := xml.Unmarshal(atomOut.Bytes(), &a); err != nil {
+				t.Fatalf("Atom is not well-formed: %v\n%s", err, atomOut.String())
+			}
+			if a.Title != opts.Title {
+				t.Errorf("Atom title = %q, want %q", a.Title, opts.Title)
+			}
+			if len(a.Entries) != 1 {
+				t.Fatalf("Atom has %d entries, want 1", len(a.Entries))
+			}
+			entry := a.Entries[0]
+			if entry.Title != tt.post.Title || entry.Summary != tt.post.Summary || entry.Author != tt.post.Author {
+				t.Errorf("Atom entry = %q, %q, %q, want %q, %q, %q",
+					entry.Title, entry.Summary, entry.Author, tt.post.Title, tt.post.Summary, tt.post.Author)
+			}
+			for i, c := range entry.Categories {
+				if c.Term != tt.post.Tags[i] {
+					t.Errorf("Atom category %d = %q, want %q", i, c.Term, tt.post.Tags[i])
+				}
+			}
+			if !strings.Contains(entry.Content, tt.wantContent) {
+				t.Errorf("Atom content = %q, want it to contain %q", entry.Content, tt.wantContent)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package finger answers Finger protocol (RFC 1288) queries with a short
+// plain-text profile of the portfolio's author.
+package finger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Arpan-206/terminal-portfolio/server"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+	"github.com/charmbracelet/log"
+)
+
+// latestPosts is how many recent post titles are listed
+const latestPosts = 3
+
+// maxQueryLength bounds a query line; real queries are a user name at most
+const maxQueryLength = 512
+
+// Handler answers Finger queries from content loaded at startup
+type Handler struct {
+	Content tui.Content
+}
+
+// Handle answers a single Finger query on conn
+func (h Handler) Handle(conn net.Conn) {
+	line, err := server.ReadLine(conn, maxQueryLength)
+	if errors.Is(err, server.ErrLineTooLong) {
+		fmt.Fprint(conn, "Query too long.\r\n")
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	// A leading /W asks for verbose output, which is the only kind served
+	query := strings.TrimSpace(strings.TrimRight(line, "\r\n"))
+	query = strings.TrimSpace(strings.TrimPrefix(query, "/W"))
+
+	log.Info("request", "protocol", "finger", "remote", conn.RemoteAddr().String(), "query", query)
+
+	w := bufio.NewWriter(conn)
+	defer w.Flush()
+
+	if strings.Contains(query, "@") {
+		writeLines(w, "Finger forwarding is not supported.")
+		return
+	}
+
+	profile := h.Content.Profile
+	if query != "" && !strings.EqualFold(query, profile.Login) {
+		writeLines(w, fmt.Sprintf("finger: %s: no such user.", query))
+		return
+	}
+
+	writeLines(w, response(profile, h.Content.Posts)...)
+}
+
+// response formats the Finger response for profile and its latest posts
+func response(profile tui.Profile, posts []tui.BlogPost) []string {
+	lines := []string{
+		fmt.Sprintf("Login: %-24s Name: %s", profile.Login, profile.Name),
+		"",
+		fmt.Sprintf("%s, %s.", profile.Name, profile.Tagline),
+		"",
+		"Status: " + profile.Status,
+		"",
+	}
+
+	if len(posts) > 0 {
+		lines = append(lines, "Latest posts:")
+		for i, post := range posts {
+			if i == latestPosts {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("  %s  %s", post.Date.Format("2006-01-02"), post.Title))
+		}
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, "Contact:")
+	for _, link := range profile.Links() {
+		lines = append(lines, fmt.Sprintf("  %-9s %s", link.Label+":", link.URL))
+	}
+
+	return lines
+}
+
+func writeLines(w *bufio.Writer, lines ...string) {
+	for _, line := range lines {
+		w.WriteString(line + "\r\n")
+	}
+}
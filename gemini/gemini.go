@@ -0,0 +1,142 @@
+// Package gemini serves the portfolio over the Gemini protocol, converting
+// the shared markdown content to gemtext.
+package gemini
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/Arpan-206/terminal-portfolio/server"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+	"github.com/charmbracelet/log"
+)
+
+// maxRequestLength is the longest request line permitted by the spec,
+// excluding the trailing CRLF
+const maxRequestLength = 1024
+
+// Gemini response status codes
+const (
+	statusSuccess    = 20
+	statusNotFound   = 51
+	statusBadRequest = 59
+)
+
+// Handler serves Gemini requests from content loaded at startup
+type Handler struct {
+	Content tui.Content
+}
+
+// Handle serves a single Gemini request on conn, which must already be a
+// TLS connection
+func (h Handler) Handle(conn net.Conn) {
+	line, err := server.ReadLine(conn, maxRequestLength+2)
+	if err != nil {
+		respond(conn, statusBadRequest, "Bad request", "")
+		return
+	}
+
+	u, err := url.Parse(strings.TrimRight(line, "\r\n"))
+	if err != nil || (u.Scheme != "" && u.Scheme != "gemini") {
+		respond(conn, statusBadRequest, "Only gemini:// URLs are served", "")
+		return
+	}
+
+	log.Info("request", "protocol", "gemini", "remote", conn.RemoteAddr().String(), "path", u.Path)
+
+	body, ok := h.page(u.Path)
+	if !ok {
+		respond(conn, statusNotFound, "Not found", "")
+		return
+	}
+	respond(conn, statusSuccess, "text/gemini; charset=utf-8", body)
+}
+
+func respond(conn net.Conn, status int, meta, body string) {
+	fmt.Fprintf(conn, "%d %s\r\n", status, meta)
+	if body != "" {
+		conn.Write([]byte(body))
+	}
+}
+
+// page returns the gemtext for a request path
+func (h Handler) page(path string) (string, bool) {
+	path = "/" + strings.Trim(path, "/")
+
+	pages := h.Content.Pages
+	for _, p := range pages {
+		if pagePath(p) != path {
+			continue
+		}
+		switch p.ID {
+		case tui.ProjectsPageID:
+			return withNav(pages, MarkdownToGemtext(tui.ProjectsMarkdown(h.Content.Projects, h.Content.Profile))), true
+		case tui.BlogPageID:
+			return withNav(pages, h.blogIndex()), true
+		default:
+			return withNav(pages, MarkdownToGemtext(p.Content)), true
+		}
+	}
+
+	if id, ok := strings.CutPrefix(path, "/blog/"); ok {
+		for _, post := range h.Content.Posts {
+			if post.ID == id {
+				return postPage(post), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (h Handler) blogIndex() string {
+	var b strings.Builder
+	b.WriteString("# 📚 Blog Posts\n\n")
+	for _, post := range h.Content.Posts {
+		fmt.Fprintf(&b, "=> /blog/%s %s %s\n", post.ID, post.Date.Format("2006-01-02"), post.Title)
+	}
+	return b.String()
+}
+
+func postPage(post tui.BlogPost) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", post.Title)
+	fmt.Fprintf(&b, "📅 %s", post.Date.Format("2006-01-02"))
+	if post.Author != "" {
+		fmt.Fprintf(&b, " · %s", post.Author)
+	}
+	b.WriteString("\n\n")
+
+	// Posts usually open with their own title heading, which would repeat
+	// the one above
+	content := MarkdownToGemtext(post.Content)
+	content = strings.TrimPrefix(content, "# "+post.Title+"\n")
+	content = strings.TrimLeft(content, "\n")
+
+	b.WriteString(content)
+	b.WriteString("\n=> /blog ← Back to all posts\n")
+	return b.String()
+}
+
+// pagePath returns the request path of a top-level page. The home page is
+// served at the root.
+func pagePath(page tui.ContentPage) string {
+	if page.ID == "home" {
+		return "/"
+	}
+	return "/" + page.ID
+}
+
+// withNav appends links to the other sections
+func withNav(pages []tui.ContentPage, body string) string {
+	var b strings.Builder
+	b.WriteString(body + "\n")
+	for _, page := range pages {
+		if !page.Hidden {
+			fmt.Fprintf(&b, "=> %s %s\n", pagePath(page), page.Title)
+		}
+	}
+	return b.String()
+}
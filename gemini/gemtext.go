@@ -0,0 +1,167 @@
+package gemini
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// linkPattern matches inline markdown links and images
+	linkPattern = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+
+	headingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	listPattern     = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+(.*)$`)
+	rulePattern     = regexp.MustCompile(`^\s*(?:-{3,}|\*{3,}|_{3,})\s*$`)
+	emphasisPattern = regexp.MustCompile(`(\*\*|__)(.+?)(\*\*|__)`)
+)
+
+// link is a markdown link lifted out of running text
+type link struct {
+	URL   string
+	Label string
+}
+
+// MarkdownToGemtext converts markdown to gemtext. Headings, lists, quotes
+// and code blocks are kept; paragraphs are joined onto single lines; and
+// inline links are pulled out onto their own "=>" lines after the block
+// they appeared in, since gemtext has no inline links.
+func MarkdownToGemtext(markdown string) string {
+	var out strings.Builder
+	var paragraph []string
+	var links []link
+	inCode := false
+	inTable := false
+
+	flushLinks := func() {
+		for _, l := range links {
+			out.WriteString("=> " + l.URL)
+			if l.Label != "" && l.Label != l.URL {
+				out.WriteString(" " + l.Label)
+			}
+			out.WriteString("\n")
+		}
+		links = nil
+	}
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString(strings.Join(paragraph, " ") + "\n")
+			paragraph = nil
+		}
+		flushLinks()
+	}
+
+	closeTable := func() {
+		if inTable {
+			out.WriteString("```\n")
+			inTable = false
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Code blocks are copied verbatim as preformatted text
+		if strings.HasPrefix(trimmed, "```") {
+			flushParagraph()
+			closeTable()
+			if inCode {
+				out.WriteString("```\n")
+			} else {
+				out.WriteString("```" + strings.TrimPrefix(trimmed, "```") + "\n")
+			}
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out.WriteString(line + "\n")
+			continue
+		}
+
+		// Tables have no gemtext equivalent, so keep their layout as
+		// preformatted text
+		if strings.HasPrefix(trimmed, "|") {
+			flushParagraph()
+			if !inTable {
+				out.WriteString("```\n")
+				inTable = true
+			}
+			out.WriteString(trimmed + "\n")
+			continue
+		}
+		closeTable()
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			out.WriteString("\n")
+
+		case rulePattern.MatchString(trimmed):
+			flushParagraph()
+
+		case headingPattern.MatchString(trimmed):
+			flushParagraph()
+			m := headingPattern.FindStringSubmatch(trimmed)
+			level := len(m[1])
+			if level > 3 {
+				level = 3
+			}
+			out.WriteString(strings.Repeat("#", level) + " " + inline(m[2], &links) + "\n")
+			flushLinks()
+
+		case listPattern.MatchString(line):
+			flushParagraph()
+			m := listPattern.FindStringSubmatch(line)
+			out.WriteString("* " + inline(m[1], &links) + "\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			out.WriteString("> " + inline(strings.TrimSpace(strings.TrimPrefix(trimmed, ">")), &links) + "\n")
+
+		default:
+			paragraph = append(paragraph, inline(trimmed, &links))
+		}
+	}
+
+	flushParagraph()
+	closeTable()
+	if inCode {
+		out.WriteString("```\n")
+	}
+
+	return collapseBlankLines(out.String())
+}
+
+// inline strips emphasis markers and replaces links with their label,
+// collecting the links so they can be emitted as link lines
+func inline(text string, links *[]link) string {
+	text = linkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		m := linkPattern.FindStringSubmatch(match)
+		*links = append(*links, link{URL: m[2], Label: m[1]})
+		return m[1]
+	})
+	return emphasisPattern.ReplaceAllString(text, "$2")
+}
+
+// collapseBlankLines limits runs of blank lines to one, outside of
+// preformatted blocks
+func collapseBlankLines(text string) string {
+	var out strings.Builder
+	blank := false
+	pre := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if strings.HasPrefix(line, "```") {
+			pre = !pre
+		}
+		if !pre && line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.String()
+}
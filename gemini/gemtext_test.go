@@ -0,0 +1,70 @@
+package gemini
+
+import "testing"
+
+func TestMarkdownToGemtext(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{
+			name:     "paragraph lines are joined",
+			markdown: "One line\nand the next.",
+			want:     "One line and the next.\n",
+		},
+		{
+			name:     "headings deeper than three are clamped",
+			markdown: "# Title\n#### Deep",
+			want:     "# Title\n### Deep\n",
+		},
+		{
+			name:     "inline links move below the paragraph",
+			markdown: "See [the docs](https://example.com/docs) and [https://example.com](https://example.com).\n\nNext.",
+			want:     "See the docs and https://example.com.\n=> https://example.com/docs the docs\n=> https://example.com\n\nNext.\n",
+		},
+		{
+			name:     "links in headings follow the heading",
+			markdown: "## About [me](/about)",
+			want:     "## About me\n=> /about me\n",
+		},
+		{
+			name:     "lists and quotes",
+			markdown: "- **bold** item\n2. second\n> quoted __text__",
+			want:     "* bold item\n* second\n> quoted text\n",
+		},
+		{
+			name:     "code blocks are kept verbatim",
+			markdown: "```go\n# not a heading\n[not](a-link)\n```",
+			want:     "```go\n# not a heading\n[not](a-link)\n```\n",
+		},
+		{
+			name:     "unterminated code block is closed",
+			markdown: "```\ncode",
+			want:     "```\ncode\n```\n",
+		},
+		{
+			name:     "tables become preformatted",
+			markdown: "| a | b |\n|---|---|\n| 1 | 2 |\nAfter",
+			want:     "```\n| a | b |\n|---|---|\n| 1 | 2 |\n```\nAfter\n",
+		},
+		{
+			name:     "rules are dropped and blank lines collapsed",
+			markdown: "Above\n\n---\n\n\n\nBelow",
+			want:     "Above\n\nBelow\n",
+		},
+		{
+			name:     "CRLF line endings",
+			markdown: "# Title\r\nText\r\n",
+			want:     "# Title\nText\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToGemtext(tt.markdown); got != tt.want {
+				t.Errorf("MarkdownToGemtext(%q)\n got: %q\nwant: %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+// Package gopher serves the portfolio over the Gopher protocol (RFC 1436)
+// with generated gophermaps and plain-text renderings of each page.
+package gopher
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/Arpan-206/terminal-portfolio/server"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+	"github.com/charmbracelet/log"
+)
+
+// textWidth is the column width plain-text documents are wrapped to
+const textWidth = 70
+
+// maxRequestLength bounds a request line. Selectors are at most 255 bytes,
+// leaving room for the fields Gopher+ clients append.
+const maxRequestLength = 1024
+
+// Gopher item types
+const (
+	itemText  = '0'
+	itemMenu  = '1'
+	itemError = '3'
+	itemInfo  = 'i'
+	itemHTML  = 'h'
+)
+
+// Handler serves Gopher requests from content loaded at startup. Hostname
+// and Port are advertised in menu items so clients request documents from
+// the right place.
+type Handler struct {
+	Hostname string
+	Port     string
+	Content  tui.Content
+}
+
+// Handle serves a single Gopher request on conn
+func (h Handler) Handle(conn net.Conn) {
+	line, err := server.ReadLine(conn, maxRequestLength)
+	if errors.Is(err, server.ErrLineTooLong) {
+		h.writeMenu(conn, []item{{Type: itemError, Display: "Request too long"}})
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	// Gopher+ clients may append tab-separated fields after the selector
+	selector, _, _ := strings.Cut(strings.TrimRight(line, "\r\n"), "\t")
+	selector = "/" + strings.Trim(selector, "/")
+
+	log.Info("request", "protocol", "gopher", "remote", conn.RemoteAddr().String(), "selector", selector)
+
+	switch {
+	case selector == "/":
+		h.writeMenu(conn, h.rootMenu())
+	case selector == "/blog":
+		h.writeMenu(conn, h.blogMenu())
+	case selector == "/projects":
+		h.writeMenu(conn, h.projectsMenu())
+	default:
+		text, ok := h.document(selector)
+		if !ok {
+			h.writeMenu(conn, []item{{Type: itemError, Display: "Not found: " + selector}})
+			return
+		}
+		writeText(conn, text)
+	}
+}
+
+// item is a single gophermap line
+type item struct {
+	Type     byte
+	Display  string
+	Selector string
+}
+
+func info(text string) item {
+	return item{Type: itemInfo, Display: text}
+}
+
+func (h Handler) rootMenu() []item {
+	items := []item{info("📍 Arpan's Portfolio"), info("")}
+	for _, page := range h.Content.Pages {
+		if page.Hidden {
+			continue
+		}
+		itemType := byte(itemText)
+		if page.ID == tui.ProjectsPageID || page.ID == tui.BlogPageID {
+			itemType = itemMenu
+		}
+		items = append(items, item{Type: itemType, Display: page.Title, Selector: "/" + page.ID})
+	}
+	items = append(items, info(""))
+	for _, link := range h.Content.Profile.Links() {
+		items = append(items, item{Type: itemHTML, Display: link.Label, Selector: "URL:" + link.URL})
+	}
+	return items
+}
+
+// blogMenu lists published posts newest first, following the same rules
+// as the terminal UI
+func (h Handler) blogMenu() []item {
+	items := []item{info("📚 Blog Posts"), info("")}
+	for _, post := range h.Content.Posts {
+		items = append(items, item{
+			Type:     itemText,
+			Display:  post.Date.Format("2006-01-02") + " " + post.Title,
+			Selector: "/blog/" + post.ID,
+		})
+	}
+	return items
+}
+
+func (h Handler) projectsMenu() []item {
+	items := []item{info("🛠️ Featured Projects"), info("")}
+	for _, project := range h.Content.Projects {
+		items = append(items, item{
+			Type:     itemText,
+			Display:  project.Name + " [" + project.Status + "]",
+			Selector: "/projects/" + tui.Slugify(project.Name),
+		})
+	}
+	return items
+}
+
+// document returns the plain-text rendering for a document selector
+func (h Handler) document(selector string) (string, bool) {
+	for _, page := range h.Content.Pages {
+		if "/"+page.ID == selector {
+			return tui.RenderPlainText(page.Content, textWidth), true
+		}
+	}
+
+	if id, ok := strings.CutPrefix(selector, "/blog/"); ok {
+		for _, post := range h.Content.Posts {
+			if post.ID == id {
+				header := post.Date.Format("2006-01-02")
+				if post.Author != "" {
+					header += " · " + post.Author
+				}
+				return header + "\n\n" + tui.RenderPlainText(post.Content, textWidth), true
+			}
+		}
+	}
+
+	if slug, ok := strings.CutPrefix(selector, "/projects/"); ok {
+		for _, project := range h.Content.Projects {
+			if tui.Slugify(project.Name) == slug {
+				return tui.RenderPlainText(tui.ProjectMarkdown(project), textWidth), true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func (h Handler) writeMenu(conn net.Conn, items []item) {
+	w := bufio.NewWriter(conn)
+	for _, it := range items {
+		host, port := h.Hostname, h.Port
+		if it.Type == itemInfo || it.Type == itemError {
+			host, port = "error.host", "1"
+		}
+		fmt.Fprintf(w, "%c%s\t%s\t%s\t%s\r\n", it.Type, it.Display, it.Selector, host, port)
+	}
+	w.WriteString(".\r\n")
+	w.Flush()
+}
+
+// writeText sends a text document with CRLF line endings, dot-stuffing
+// lines that begin with a period
+func writeText(conn net.Conn, text string) {
+	w := bufio.NewWriter(conn)
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		line = strings.TrimRight(line, " ")
+		if strings.HasPrefix(line, ".") {
+			line = "." + line
+		}
+		w.WriteString(line + "\r\n")
+	}
+	w.WriteString(".\r\n")
+	w.Flush()
+}
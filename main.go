@@ -5,37 +5,201 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"flag"
+	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/Arpan-206/terminal-portfolio/admin"
+	"github.com/Arpan-206/terminal-portfolio/feed"
+	"github.com/Arpan-206/terminal-portfolio/finger"
+	"github.com/Arpan-206/terminal-portfolio/gemini"
+	"github.com/Arpan-206/terminal-portfolio/gopher"
+	"github.com/Arpan-206/terminal-portfolio/proxyproto"
+	"github.com/Arpan-206/terminal-portfolio/recorder"
+	"github.com/Arpan-206/terminal-portfolio/server"
+	"github.com/Arpan-206/terminal-portfolio/site"
+	"github.com/Arpan-206/terminal-portfolio/telnet"
 	"github.com/Arpan-206/terminal-portfolio/tui"
+	"github.com/Arpan-206/terminal-portfolio/visitor"
+	"github.com/Arpan-206/terminal-portfolio/web"
 	"github.com/charmbracelet/log"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
-	"github.com/charmbracelet/wish/logging"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 const (
 	host = "0.0.0.0"
 )
 
+// logFormatter maps the LOG_FORMAT setting onto a charmbracelet/log formatter
+func logFormatter(format string) (log.Formatter, error) {
+	switch format {
+	case "", "text":
+		return log.TextFormatter, nil
+	case "json":
+		return log.JSONFormatter, nil
+	case "logfmt":
+		return log.LogfmtFormatter, nil
+	default:
+		return log.TextFormatter, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// listenSSH opens the SSH listener, wrapping it with PROXY protocol
+// parsing when PROXY_PROTOCOL is enabled. PROXY_TRUSTED_CIDRS lists the
+// load balancers that may send headers and must be set, since anyone else
+// could use a header to forge their address.
+func listenSSH(addr string) (net.Listener, error) {
+	enabled, _ := strconv.ParseBool(os.Getenv("PROXY_PROTOCOL"))
+	if !enabled {
+		return net.Listen("tcp", addr)
+	}
+
+	trusted, err := proxyproto.ParseCIDRs(os.Getenv("PROXY_TRUSTED_CIDRS"))
+	if err != nil {
+		return nil, err
+	}
+	if len(trusted) == 0 {
+		return nil, errors.New("PROXY_TRUSTED_CIDRS must list the load balancers allowed to send PROXY headers")
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("PROXY protocol enabled", "trusted", os.Getenv("PROXY_TRUSTED_CIDRS"))
+	return &proxyproto.Listener{Listener: ln, Trusted: trusted}, nil
+}
+
+// buildSite implements the build-site command, rendering the portfolio to
+// a static HTML directory
+func buildSite(args []string) error {
+	flags := flag.NewFlagSet("build-site", flag.ExitOnError)
+	out := flags.String("out", "public", "directory to write the site to")
+	base := flags.String("base", "/", "URL path the site is served under")
+	flags.Parse(args)
+
+	if err := site.Build(site.Options{OutDir: *out, Base: *base}); err != nil {
+		return err
+	}
+	log.Info("Built static site", "out", *out)
+	return nil
+}
+
+// buildFeeds implements the build-feeds command, writing RSS and Atom
+// feeds of the published blog posts
+func buildFeeds(args []string) error {
+	flags := flag.NewFlagSet("build-feeds", flag.ExitOnError)
+	out := flags.String("out", "public", "directory to write rss.xml and atom.xml to")
+	siteURL := flags.String("site-url", os.Getenv("FEED_SITE_URL"), "absolute URL of the static site")
+	flags.Parse(args)
+
+	if *siteURL == "" {
+		return errors.New("a site URL is required, pass -site-url or set FEED_SITE_URL")
+	}
+
+	if err := feed.WriteFiles(*out, feed.Options{SiteURL: *siteURL}, tui.LoadBlogPosts()); err != nil {
+		return err
+	}
+	log.Info("Built feeds", "out", *out)
+	return nil
+}
+
 func main() {
+	// Choose log output format from environment: text (default), json or logfmt
+	formatter, err := logFormatter(os.Getenv("LOG_FORMAT"))
+	if err != nil {
+		log.Warn("Falling back to text logs", "error", err)
+	}
+	log.SetFormatter(formatter)
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "build-site":
+			if err := buildSite(os.Args[2:]); err != nil {
+				log.Error("Could not build site", "error", err)
+				os.Exit(1)
+			}
+		case "build-feeds":
+			if err := buildFeeds(os.Args[2:]); err != nil {
+				log.Error("Could not build feeds", "error", err)
+				os.Exit(1)
+			}
+		default:
+			log.Error("Unknown command", "command", os.Args[1])
+			os.Exit(2)
+		}
+		return
+	}
+
 	// Choose port from environment or fallback to 2222
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "2222"
 	}
 
+	// Session recording is configured from environment and can be toggled
+	// at runtime through the admin listener
+	recordDir := os.Getenv("RECORD_DIR")
+	if recordDir == "" {
+		recordDir = "recordings"
+	}
+	recordKeep, err := strconv.Atoi(os.Getenv("RECORD_KEEP"))
+	if err != nil {
+		recordKeep = 50
+	}
+	rec := recorder.New(recordDir, recordKeep)
+	if enabled, _ := strconv.ParseBool(os.Getenv("RECORD_SESSIONS")); enabled {
+		rec.SetEnabled(true)
+	}
+
+	// Reading positions are remembered for visitors who connect with a
+	// public key
+	visitorPath := os.Getenv("VISITOR_STORE")
+	if visitorPath == "" {
+		visitorPath = "data/visitors.json"
+	}
+	visitors, err := visitor.Open(visitorPath)
+	if err != nil {
+		log.Error("Could not open visitor store", "path", visitorPath, "error", err)
+		return
+	}
+
+	// Key bindings can be overridden from a JSON file
+	if keymapPath := os.Getenv("KEYMAP_FILE"); keymapPath != "" {
+		keys, err := tui.LoadKeyMap(keymapPath)
+		if err != nil {
+			log.Error("Could not load keymap", "path", keymapPath, "error", err)
+			return
+		}
+		tui.SetKeyMap(keys)
+	}
+
+	// Content for the Gemini, Gopher and Finger servers and the admin feeds
+	// is read once here rather than on every request
+	content := tui.LoadContent()
+
 	s, err := wish.NewServer(
 		wish.WithAddress(net.JoinHostPort(host, port)),
 		wish.WithHostKeyPath(".ssh/id_ed25519"),
+		// Any key is accepted; it only identifies returning visitors.
+		// Clients without a key get in through keyboard-interactive auth
+		// with no questions asked.
+		wish.WithPublicKeyAuth(func(ssh.Context, ssh.PublicKey) bool { return true }),
+		wish.WithKeyboardInteractiveAuth(func(ssh.Context, gossh.KeyboardInteractiveChallenge) bool { return true }),
 		wish.WithMiddleware(
-			tui.CustomBubbleteaMiddleware(),
-			logging.Middleware(),
+			tui.CustomBubbleteaMiddleware(rec, visitors),
+			tui.LoggingMiddleware(),
 		),
 	)
 	if err != nil {
@@ -43,16 +207,148 @@ func main() {
 		return
 	}
 
+	ln, err := listenSSH(net.JoinHostPort(host, port))
+	if err != nil {
+		log.Error("Could not start server", "error", err)
+		return
+	}
+
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 	log.Info("Starting SSH server", "host", host, "port", port)
 	go func() {
-		if err = s.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+		if err = s.Serve(ln); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
 			log.Error("Could not start server", "error", err)
 			done <- nil
 		}
 	}()
 
+	// Optional telnet front-end, only started when TELNET_PORT is set
+	var telnetServer *telnet.Server
+	if telnetPort := os.Getenv("TELNET_PORT"); telnetPort != "" {
+		telnetLn, err := net.Listen("tcp", net.JoinHostPort(host, telnetPort))
+		if err != nil {
+			log.Error("Could not start telnet server", "error", err)
+			return
+		}
+		telnetServer = &telnet.Server{Recorder: rec}
+		log.Info("Starting telnet server", "host", host, "port", telnetPort)
+		go func() {
+			if err := telnetServer.Serve(telnetLn); err != nil && !errors.Is(err, telnet.ErrServerClosed) {
+				log.Error("Could not start telnet server", "error", err)
+			}
+		}()
+	}
+
+	// Optional browser terminal, only started when WEB_PORT is set
+	var webServer *http.Server
+	if webPort := os.Getenv("WEB_PORT"); webPort != "" {
+		handler, err := web.NewHandler(rec)
+		if err != nil {
+			log.Error("Could not start web server", "error", err)
+			return
+		}
+		webLn, err := net.Listen("tcp", net.JoinHostPort(host, webPort))
+		if err != nil {
+			log.Error("Could not start web server", "error", err)
+			return
+		}
+		webServer = &http.Server{Handler: handler}
+		log.Info("Starting web server", "host", host, "port", webPort)
+		go func() {
+			if err := webServer.Serve(webLn); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Could not start web server", "error", err)
+			}
+		}()
+	}
+
+	// Optional Gemini listener, only started when GEMINI_PORT is set. A
+	// self-signed certificate for GEMINI_HOSTNAME is generated on first run.
+	var geminiServer *server.Server
+	if geminiPort := os.Getenv("GEMINI_PORT"); geminiPort != "" {
+		hostname := os.Getenv("GEMINI_HOSTNAME")
+		if hostname == "" {
+			hostname = "localhost"
+		}
+		cert, err := gemini.LoadOrCreateCertificate(".gemini/cert.pem", ".gemini/key.pem", hostname)
+		if err != nil {
+			log.Error("Could not load Gemini certificate", "error", err)
+			return
+		}
+		geminiLn, err := net.Listen("tcp", net.JoinHostPort(host, geminiPort))
+		if err != nil {
+			log.Error("Could not start Gemini server", "error", err)
+			return
+		}
+		geminiLn = tls.NewListener(geminiLn, &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		})
+		geminiServer = &server.Server{Handler: gemini.Handler{Content: content}.Handle}
+		log.Info("Starting Gemini server", "host", host, "port", geminiPort, "hostname", hostname)
+		go func() {
+			if err := geminiServer.Serve(geminiLn); err != nil && !errors.Is(err, server.ErrServerClosed) {
+				log.Error("Could not start Gemini server", "error", err)
+			}
+		}()
+	}
+
+	// Optional Gopher listener, only started when GOPHER_PORT is set.
+	// GOPHER_HOSTNAME is the host advertised in menus.
+	var gopherServer *server.Server
+	if gopherPort := os.Getenv("GOPHER_PORT"); gopherPort != "" {
+		hostname := os.Getenv("GOPHER_HOSTNAME")
+		if hostname == "" {
+			hostname = "localhost"
+		}
+		gopherLn, err := net.Listen("tcp", net.JoinHostPort(host, gopherPort))
+		if err != nil {
+			log.Error("Could not start Gopher server", "error", err)
+			return
+		}
+		gopherServer = &server.Server{Handler: gopher.Handler{Hostname: hostname, Port: gopherPort, Content: content}.Handle}
+		log.Info("Starting Gopher server", "host", host, "port", gopherPort, "hostname", hostname)
+		go func() {
+			if err := gopherServer.Serve(gopherLn); err != nil && !errors.Is(err, server.ErrServerClosed) {
+				log.Error("Could not start Gopher server", "error", err)
+			}
+		}()
+	}
+
+	// Optional Finger responder, only started when FINGER_PORT is set
+	var fingerServer *server.Server
+	if fingerPort := os.Getenv("FINGER_PORT"); fingerPort != "" {
+		fingerLn, err := net.Listen("tcp", net.JoinHostPort(host, fingerPort))
+		if err != nil {
+			log.Error("Could not start Finger server", "error", err)
+			return
+		}
+		fingerServer = &server.Server{Handler: finger.Handler{Content: content}.Handle}
+		log.Info("Starting Finger server", "host", host, "port", fingerPort)
+		go func() {
+			if err := fingerServer.Serve(fingerLn); err != nil && !errors.Is(err, server.ErrServerClosed) {
+				log.Error("Could not start Finger server", "error", err)
+			}
+		}()
+	}
+
+	// Optional admin HTTP listener, only started when ADMIN_ADDR is set
+	var adminServer *http.Server
+	if adminAddr := os.Getenv("ADMIN_ADDR"); adminAddr != "" {
+		// Feeds are served when FEED_SITE_URL says where posts are published
+		var feeds *feed.Options
+		if siteURL := os.Getenv("FEED_SITE_URL"); siteURL != "" {
+			feeds = &feed.Options{SiteURL: siteURL}
+		}
+		adminServer = &http.Server{Addr: adminAddr, Handler: admin.NewHandler(rec, feeds, content.Posts)}
+		log.Info("Starting admin server", "addr", adminAddr)
+		go func() {
+			if err := adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Error("Could not start admin server", "error", err)
+			}
+		}()
+	}
+
 	<-done
 	log.Info("Stopping SSH server")
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -60,4 +356,37 @@ func main() {
 	if err := s.Shutdown(ctx); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
 		log.Error("Could not stop server", "error", err)
 	}
+	if telnetServer != nil {
+		if err := telnetServer.Shutdown(ctx); err != nil {
+			log.Error("Could not stop telnet server", "error", err)
+		}
+	}
+	if webServer != nil {
+		if err := webServer.Shutdown(ctx); err != nil {
+			log.Error("Could not stop web server", "error", err)
+		}
+	}
+	if geminiServer != nil {
+		if err := geminiServer.Shutdown(ctx); err != nil {
+			log.Error("Could not stop Gemini server", "error", err)
+		}
+	}
+	if gopherServer != nil {
+		if err := gopherServer.Shutdown(ctx); err != nil {
+			log.Error("Could not stop Gopher server", "error", err)
+		}
+	}
+	if fingerServer != nil {
+		if err := fingerServer.Shutdown(ctx); err != nil {
+			log.Error("Could not stop Finger server", "error", err)
+		}
+	}
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			log.Error("Could not stop admin server", "error", err)
+		}
+	}
+	if err := visitors.Save(); err != nil {
+		log.Error("Could not save visitor store", "path", visitors.Path(), "error", err)
+	}
 }
@@ -0,0 +1,298 @@
+// Package proxyproto implements the receiving side of the HAProxy PROXY
+// protocol (versions 1 and 2) so that connections arriving through a TCP
+// load balancer report the real client address from RemoteAddr.
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// v2Signature prefixes every version 2 header
+var v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+const (
+	// v1MaxLength is the longest header permitted by the version 1 spec
+	v1MaxLength = 107
+
+	// DefaultHeaderTimeout bounds how long a connection may take to send its header
+	DefaultHeaderTimeout = 5 * time.Second
+)
+
+// ErrInvalidHeader is returned when a trusted peer sends a malformed header
+var ErrInvalidHeader = errors.New("proxyproto: invalid header")
+
+// Listener wraps a net.Listener and strips PROXY protocol headers from
+// connections that originate from a trusted source
+type Listener struct {
+	net.Listener
+
+	// Trusted lists the networks allowed to send PROXY headers. Connections
+	// from anywhere else are passed through untouched, so when it is empty
+	// no headers are parsed at all.
+	Trusted []*net.IPNet
+
+	// HeaderTimeout bounds how long header parsing may block. Zero means
+	// DefaultHeaderTimeout.
+	HeaderTimeout time.Duration
+}
+
+// ParseCIDRs parses a comma separated list of CIDRs or bare IP addresses
+func ParseCIDRs(list string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if !strings.Contains(item, "/") {
+			ip := net.ParseIP(item)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted address %q", item)
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted network %q: %w", item, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	return nets, nil
+}
+
+// Accept waits for the next connection. Header parsing is deferred to the
+// connection's first Read or RemoteAddr call so a slow peer cannot stall
+// the accept loop.
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	if !l.trusted(conn.RemoteAddr()) {
+		return conn, nil
+	}
+
+	timeout := l.HeaderTimeout
+	if timeout == 0 {
+		timeout = DefaultHeaderTimeout
+	}
+
+	return &Conn{
+		Conn:    conn,
+		reader:  bufio.NewReader(conn),
+		timeout: timeout,
+	}, nil
+}
+
+func (l *Listener) trusted(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+
+	for _, n := range l.Trusted {
+		if n.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// Conn is a connection from a trusted source whose PROXY header, if any,
+// has been consumed. RemoteAddr and LocalAddr report the addresses carried
+// in the header.
+type Conn struct {
+	net.Conn
+
+	reader  *bufio.Reader
+	timeout time.Duration
+
+	once       sync.Once
+	err        error
+	remoteAddr net.Addr
+	localAddr  net.Addr
+}
+
+// Read reads data following the PROXY header
+func (c *Conn) Read(b []byte) (int, error) {
+	c.once.Do(c.readHeader)
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+// RemoteAddr returns the client address from the PROXY header, or the
+// peer address if no header was sent
+func (c *Conn) RemoteAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	if c.remoteAddr != nil {
+		return c.remoteAddr
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the destination address from the PROXY header, or the
+// listener address if no header was sent
+func (c *Conn) LocalAddr() net.Addr {
+	c.once.Do(c.readHeader)
+	if c.localAddr != nil {
+		return c.localAddr
+	}
+	return c.Conn.LocalAddr()
+}
+
+func (c *Conn) readHeader() {
+	c.Conn.SetReadDeadline(time.Now().Add(c.timeout))
+	defer c.Conn.SetReadDeadline(time.Time{})
+
+	first, err := c.reader.Peek(1)
+	if err != nil {
+		c.err = err
+		return
+	}
+
+	switch first[0] {
+	case 'P':
+		c.err = c.readV1()
+	case v2Signature[0]:
+		c.err = c.readV2()
+	}
+}
+
+// readV1 parses a human-readable header such as
+// "PROXY TCP4 192.0.2.1 198.51.100.1 56324 22\r\n"
+func (c *Conn) readV1() error {
+	prefix, err := c.reader.Peek(6)
+	if err != nil {
+		return err
+	}
+	if string(prefix) != "PROXY " {
+		return nil
+	}
+
+	var line []byte
+	for len(line) < v1MaxLength {
+		b, err := c.reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		line = append(line, b)
+		if bytes.HasSuffix(line, []byte("\r\n")) {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return ErrInvalidHeader
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) < 2 {
+		return ErrInvalidHeader
+	}
+
+	switch fields[1] {
+	case "UNKNOWN":
+		return nil
+	case "TCP4", "TCP6":
+		if len(fields) != 6 {
+			return ErrInvalidHeader
+		}
+	default:
+		return ErrInvalidHeader
+	}
+
+	src, err := parseV1Addr(fields[2], fields[4])
+	if err != nil {
+		return err
+	}
+	dst, err := parseV1Addr(fields[3], fields[5])
+	if err != nil {
+		return err
+	}
+
+	c.remoteAddr, c.localAddr = src, dst
+	return nil
+}
+
+func parseV1Addr(host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, ErrInvalidHeader
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, ErrInvalidHeader
+	}
+	return &net.TCPAddr{IP: ip, Port: int(p)}, nil
+}
+
+// readV2 parses the binary header: a 12 byte signature, version and
+// command, address family, payload length and the address block
+func (c *Conn) readV2() error {
+	header, err := c.reader.Peek(16)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(header[:12], v2Signature) {
+		return nil
+	}
+
+	verCmd, family := header[12], header[13]
+	length := int(binary.BigEndian.Uint16(header[14:16]))
+	if verCmd>>4 != 2 {
+		return ErrInvalidHeader
+	}
+
+	if _, err := c.reader.Discard(16); err != nil {
+		return err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return err
+	}
+
+	// LOCAL command: health checks from the balancer itself
+	if verCmd&0x0F == 0x0 {
+		return nil
+	}
+	if verCmd&0x0F != 0x1 {
+		return ErrInvalidHeader
+	}
+
+	switch family {
+	case 0x11: // TCP over IPv4
+		if length < 12 {
+			return ErrInvalidHeader
+		}
+		c.remoteAddr = &net.TCPAddr{IP: net.IP(payload[0:4]), Port: int(binary.BigEndian.Uint16(payload[8:10]))}
+		c.localAddr = &net.TCPAddr{IP: net.IP(payload[4:8]), Port: int(binary.BigEndian.Uint16(payload[10:12]))}
+	case 0x21: // TCP over IPv6
+		if length < 36 {
+			return ErrInvalidHeader
+		}
+		c.remoteAddr = &net.TCPAddr{IP: net.IP(payload[0:16]), Port: int(binary.BigEndian.Uint16(payload[32:34]))}
+		c.localAddr = &net.TCPAddr{IP: net.IP(payload[16:32]), Port: int(binary.BigEndian.Uint16(payload[34:36]))}
+	}
+
+	return nil
+}
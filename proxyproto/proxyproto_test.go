@@ -0,0 +1,228 @@
+package proxyproto
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+// v2Header builds a version 2 header with the given command, family and
+// address block, declaring length bytes of payload
+func v2Header(cmd, family byte, length int, payload []byte) []byte {
+	h := append([]byte(nil), v2Signature...)
+	h = append(h, 0x20|cmd, family)
+	h = binary.BigEndian.AppendUint16(h, uint16(length))
+	return append(h, payload...)
+}
+
+// tcp4Payload is the address block for 192.0.2.1:56324 -> 198.51.100.1:22
+var tcp4Payload = []byte{
+	192, 0, 2, 1,
+	198, 51, 100, 1,
+	0xdc, 0x04,
+	0x00, 0x16,
+}
+
+// serve writes input to a Conn reading from the other end of a pipe and
+// closes the writing side once it is consumed
+func serve(t *testing.T, input []byte) *Conn {
+	t.Helper()
+	client, server := net.Pipe()
+	t.Cleanup(func() { server.Close() })
+	go func() {
+		client.Write(input)
+		client.Close()
+	}()
+	return &Conn{Conn: server, reader: bufio.NewReader(server), timeout: time.Second}
+}
+
+func TestConnHeaders(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []byte
+		wantRemote string
+		wantLocal  string
+		wantErr    error
+		wantData   string
+	}{
+		{
+			name:       "v1 TCP4",
+			input:      []byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 22\r\nhello"),
+			wantRemote: "192.0.2.1:56324",
+			wantLocal:  "198.51.100.1:22",
+			wantData:   "hello",
+		},
+		{
+			name:       "v1 TCP6",
+			input:      []byte("PROXY TCP6 2001:db8::1 2001:db8::2 4000 22\r\nhello"),
+			wantRemote: "[2001:db8::1]:4000",
+			wantLocal:  "[2001:db8::2]:22",
+			wantData:   "hello",
+		},
+		{
+			name:     "v1 UNKNOWN keeps the peer address",
+			input:    []byte("PROXY UNKNOWN\r\nhello"),
+			wantData: "hello",
+		},
+		{
+			name:    "v1 without CRLF",
+			input:   append([]byte("PROXY TCP4 192.0.2.1 198.51.100.1 56324 22"), make([]byte, v1MaxLength)...),
+			wantErr: ErrInvalidHeader,
+		},
+		{
+			name:    "v1 bad address",
+			input:   []byte("PROXY TCP4 192.0.2 198.51.100.1 56324 22\r\n"),
+			wantErr: ErrInvalidHeader,
+		},
+		{
+			name:    "v1 bad port",
+			input:   []byte("PROXY TCP4 192.0.2.1 198.51.100.1 70000 22\r\n"),
+			wantErr: ErrInvalidHeader,
+		},
+		{
+			name:       "v2 PROXY TCP4",
+			input:      append(v2Header(0x1, 0x11, len(tcp4Payload), tcp4Payload), "hello"...),
+			wantRemote: "192.0.2.1:56324",
+			wantLocal:  "198.51.100.1:22",
+			wantData:   "hello",
+		},
+		{
+			name:     "v2 LOCAL keeps the peer address",
+			input:    append(v2Header(0x0, 0x00, 0, nil), "hello"...),
+			wantData: "hello",
+		},
+		{
+			name:    "v2 short address block",
+			input:   v2Header(0x1, 0x11, 4, tcp4Payload[:4]),
+			wantErr: ErrInvalidHeader,
+		},
+		{
+			name:    "v2 truncated fixed header",
+			input:   v2Header(0x1, 0x11, len(tcp4Payload), nil)[:14],
+			wantErr: io.EOF,
+		},
+		{
+			name:    "v2 truncated payload",
+			input:   v2Header(0x1, 0x11, len(tcp4Payload), tcp4Payload[:6]),
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "no header",
+			input:    []byte("SSH-2.0-client\r\n"),
+			wantData: "SSH-2.0-client\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := serve(t, tt.input)
+
+			if tt.wantErr != nil {
+				n, err := c.Read(make([]byte, 64))
+				if n != 0 || !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Read = %d, %v, want 0, %v", n, err, tt.wantErr)
+				}
+				return
+			}
+
+			data, err := io.ReadAll(c)
+			if err != nil {
+				t.Fatalf("Read error = %v", err)
+			}
+			if string(data) != tt.wantData {
+				t.Errorf("data = %q, want %q", data, tt.wantData)
+			}
+
+			wantRemote, wantLocal := tt.wantRemote, tt.wantLocal
+			if wantRemote == "" {
+				wantRemote, wantLocal = c.Conn.RemoteAddr().String(), c.Conn.LocalAddr().String()
+			}
+			if got := c.RemoteAddr().String(); got != wantRemote {
+				t.Errorf("RemoteAddr = %s, want %s", got, wantRemote)
+			}
+			if got := c.LocalAddr().String(); got != wantLocal {
+				t.Errorf("LocalAddr = %s, want %s", got, wantLocal)
+			}
+		})
+	}
+}
+
+func TestParseCIDRs(t *testing.T) {
+	tests := []struct {
+		list    string
+		want    []string
+		wantErr bool
+	}{
+		{list: "", want: nil},
+		{list: "10.0.0.0/8", want: []string{"10.0.0.0/8"}},
+		{list: " 192.0.2.1 , 2001:db8::/32,", want: []string{"192.0.2.1/32", "2001:db8::/32"}},
+		{list: "2001:db8::1", want: []string{"2001:db8::1/128"}},
+		{list: "not-an-ip", wantErr: true},
+		{list: "10.0.0.0/33", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		nets, err := ParseCIDRs(tt.list)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCIDRs(%q) error = %v, want error %v", tt.list, err, tt.wantErr)
+			continue
+		}
+		if len(nets) != len(tt.want) {
+			t.Errorf("ParseCIDRs(%q) = %v, want %v", tt.list, nets, tt.want)
+			continue
+		}
+		for i, n := range nets {
+			if n.String() != tt.want[i] {
+				t.Errorf("ParseCIDRs(%q)[%d] = %s, want %s", tt.list, i, n, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestListenerTrust(t *testing.T) {
+	tests := []struct {
+		name    string
+		trusted string
+		parsed  bool
+	}{
+		{name: "empty list trusts nobody", trusted: "", parsed: false},
+		{name: "other network", trusted: "192.0.2.0/24", parsed: false},
+		{name: "loopback", trusted: "127.0.0.1", parsed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner, err := net.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				t.Skipf("cannot listen on loopback: %v", err)
+			}
+			defer inner.Close()
+
+			trusted, err := ParseCIDRs(tt.trusted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ln := &Listener{Listener: inner, Trusted: trusted}
+
+			client, err := net.Dial("tcp", inner.Addr().String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer client.Close()
+
+			conn, err := ln.Accept()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			if _, parsed := conn.(*Conn); parsed != tt.parsed {
+				t.Errorf("header parsed = %v, want %v", parsed, tt.parsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Package recorder writes terminal sessions to asciinema's asciicast v2
+// format so rendering problems reported by visitors can be replayed.
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+)
+
+// Recorder creates recordings in a directory and enforces its retention
+// limit. It is safe for concurrent use and can be toggled at runtime.
+type Recorder struct {
+	dir     string
+	keep    int
+	enabled atomic.Bool
+
+	mu     sync.Mutex          // serialises pruning
+	active map[string]struct{} // recordings still being written
+}
+
+// New returns a Recorder writing to dir and keeping at most keep
+// recordings (zero keeps everything). Recording starts disabled.
+func New(dir string, keep int) *Recorder {
+	return &Recorder{dir: dir, keep: keep}
+}
+
+// Enabled reports whether new sessions will be recorded
+func (r *Recorder) Enabled() bool {
+	return r != nil && r.enabled.Load()
+}
+
+// SetEnabled turns recording of new sessions on or off. Sessions already
+// being recorded are unaffected.
+func (r *Recorder) SetEnabled(enabled bool) {
+	r.enabled.Store(enabled)
+}
+
+// Dir returns the directory recordings are written to
+func (r *Recorder) Dir() string {
+	return r.dir
+}
+
+// header is the first line of an asciicast v2 file
+type header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Start begins recording a session. It returns nil without error when
+// recording is disabled.
+func (r *Recorder) Start(sessionID string, width, height int, term string) (*Recording, error) {
+	if !r.Enabled() {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	name := fmt.Sprintf("%s-%s.cast", start.UTC().Format("20060102T150405Z"), sessionID)
+	f, err := os.Create(filepath.Join(r.dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &Recording{
+		recorder: r,
+		name:     name,
+		file:     f,
+		w:        bufio.NewWriter(f),
+		start:    start,
+	}
+
+	h := header{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+		Env:       map[string]string{"TERM": term},
+	}
+	if err := rec.writeLine(h); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	r.mu.Lock()
+	if r.active == nil {
+		r.active = make(map[string]struct{})
+	}
+	r.active[name] = struct{}{}
+	r.mu.Unlock()
+
+	r.prune()
+	return rec, nil
+}
+
+// prune deletes the oldest recordings beyond the retention limit, leaving
+// those still being written. File names begin with a UTC timestamp so
+// lexical order is chronological.
+func (r *Recorder) prune() {
+	if r.keep <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return
+	}
+
+	var casts []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".cast") {
+			casts = append(casts, entry.Name())
+		}
+	}
+	sort.Strings(casts)
+
+	excess := len(casts) - r.keep
+	for _, name := range casts {
+		if excess <= 0 {
+			break
+		}
+		if _, ok := r.active[name]; ok {
+			continue
+		}
+		os.Remove(filepath.Join(r.dir, name))
+		excess--
+	}
+}
+
+// finish marks a recording as no longer being written, so prune may
+// delete it
+func (r *Recorder) finish(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.active, name)
+}
+
+// Recording is a single session being written to disk. Write records
+// output events and Resize records window size changes.
+type Recording struct {
+	recorder *Recorder
+	name     string
+	mu       sync.Mutex
+	file     *os.File
+	w        *bufio.Writer
+	start    time.Time
+	pending  []byte // trailing bytes of an incomplete UTF-8 sequence
+	closed   bool
+}
+
+// Write records p as an output event. It never fails so that a recording
+// problem cannot interrupt the visitor's session.
+func (rec *Recording) Write(p []byte) (int, error) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.closed {
+		return len(p), nil
+	}
+
+	data := append(rec.pending, p...)
+	cut := completeUTF8(data)
+	rec.pending = append([]byte(nil), data[cut:]...)
+
+	if cut > 0 {
+		rec.event("o", string(data[:cut]))
+	}
+	return len(p), nil
+}
+
+// Resize records a terminal resize event
+func (rec *Recording) Resize(width, height int) {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.closed {
+		return
+	}
+	rec.event("r", fmt.Sprintf("%dx%d", width, height))
+}
+
+// Close flushes the recording to disk
+func (rec *Recording) Close() error {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+
+	if rec.closed {
+		return nil
+	}
+	rec.closed = true
+	defer rec.recorder.finish(rec.name)
+
+	if len(rec.pending) > 0 {
+		rec.event("o", string(rec.pending))
+		rec.pending = nil
+	}
+
+	if err := rec.w.Flush(); err != nil {
+		rec.file.Close()
+		return err
+	}
+	return rec.file.Close()
+}
+
+func (rec *Recording) event(kind, data string) {
+	elapsed := time.Since(rec.start).Seconds()
+	rec.writeLine([]interface{}{elapsed, kind, data})
+}
+
+func (rec *Recording) writeLine(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+	_, err = rec.w.Write(b)
+	return err
+}
+
+// completeUTF8 returns the length of the longest prefix of b that does not
+// end in the middle of a multi-byte UTF-8 sequence
+func completeUTF8(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if !utf8.RuneStart(b[i]) {
+			continue
+		}
+		if utf8.FullRune(b[i:]) {
+			return len(b)
+		}
+		return i
+	}
+	return len(b)
+}
@@ -0,0 +1,225 @@
+package recorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// readEvents returns the header and the events of a finished recording
+func readEvents(t *testing.T, path string) (header, [][]interface{}) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		t.Fatal("recording is empty")
+	}
+	var h header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		t.Fatalf("header: %v", err)
+	}
+
+	var events [][]interface{}
+	for scanner.Scan() {
+		var event []interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("event %q: %v", scanner.Text(), err)
+		}
+		events = append(events, event)
+	}
+	return h, events
+}
+
+func TestRecordingSplitsUTF8(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		want   []string
+	}{
+		{
+			name:   "ASCII",
+			writes: []string{"hello", " world"},
+			want:   []string{"hello", " world"},
+		},
+		{
+			name:   "rune split across two writes",
+			writes: []string{"caf\xc3", "\xa9!"},
+			want:   []string{"caf", "é!"},
+		},
+		{
+			name:   "four-byte rune split three ways",
+			writes: []string{"\xf0\x9f", "\x98", "\x80 ok"},
+			want:   []string{"😀 ok"},
+		},
+		{
+			name:   "box drawing split after its first byte",
+			writes: []string{"─\xe2", "\x94\x80"},
+			want:   []string{"─", "─"},
+		},
+		{
+			name:   "trailing partial rune is written on close",
+			writes: []string{"end\xe2\x94"},
+			want:   []string{"end", "\xe2\x94"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(t.TempDir(), 0)
+			r.SetEnabled(true)
+			rec, err := r.Start("session", 80, 24, "xterm")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, w := range tt.writes {
+				if n, err := rec.Write([]byte(w)); n != len(w) || err != nil {
+					t.Fatalf("Write(%q) = %d, %v", w, n, err)
+				}
+			}
+			if err := rec.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			casts, _ := filepath.Glob(filepath.Join(r.Dir(), "*.cast"))
+			if len(casts) != 1 {
+				t.Fatalf("%d recordings, want 1", len(casts))
+			}
+			h, events := readEvents(t, casts[0])
+			if h.Version != 2 || h.Width != 80 || h.Height != 24 || h.Env["TERM"] != "xterm" {
+				t.Errorf("header = %+v", h)
+			}
+
+			var got []string
+			for _, event := range events {
+				if event[1] != "o" {
+					t.Errorf("event kind = %v, want o", event[1])
+				}
+				got = append(got, event[2].(string))
+			}
+			// JSON turns invalid UTF-8 into U+FFFD, as players expect
+			want := make([]string, len(tt.want))
+			for i, w := range tt.want {
+				b, _ := json.Marshal(w)
+				json.Unmarshal(b, &want[i])
+			}
+			if fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Errorf("events = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	tests := []struct {
+		name string
+		keep int
+		// open lists which sessions are still recording when the next
+		// one starts; the rest are closed first
+		sessions []string
+		open     map[string]bool
+		want     []string
+	}{
+		{
+			name:     "keeps everything with no limit",
+			keep:     0,
+			sessions: []string{"a", "b", "c"},
+			want:     []string{"a", "b", "c"},
+		},
+		{
+			name:     "deletes the oldest finished recordings",
+			keep:     2,
+			sessions: []string{"a", "b", "c", "d"},
+			want:     []string{"c", "d"},
+		},
+		{
+			name:     "skips recordings still being written",
+			keep:     2,
+			sessions: []string{"a", "b", "c", "d"},
+			open:     map[string]bool{"a": true},
+			want:     []string{"a", "d"},
+		},
+		{
+			name:     "goes over the limit rather than delete open recordings",
+			keep:     1,
+			sessions: []string{"a", "b", "c"},
+			open:     map[string]bool{"a": true, "b": true, "c": true},
+			want:     []string{"a", "b", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			r := New(dir, tt.keep)
+			r.SetEnabled(true)
+
+			// Recordings started in the same second sort by session ID,
+			// so the IDs give the order they were started in
+			var open []*Recording
+			for _, id := range tt.sessions {
+				rec, err := r.Start(id, 80, 24, "xterm")
+				if err != nil {
+					t.Fatal(err)
+				}
+				rec.Write([]byte(id))
+				if tt.open[id] {
+					open = append(open, rec)
+					continue
+				}
+				if err := rec.Close(); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, entry := range entries {
+				name := entry.Name()
+				got = append(got, name[len(name)-len("x.cast"):len(name)-len(".cast")])
+			}
+			sort.Strings(got)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("recordings = %v, want %v", got, tt.want)
+			}
+
+			// Open recordings still finish cleanly
+			for _, rec := range open {
+				rec.Write([]byte(" more"))
+				if err := rec.Close(); err != nil {
+					t.Errorf("Close = %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestDisabledRecorder(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recordings")
+	r := New(dir, 1)
+
+	rec, err := r.Start("session", 80, 24, "xterm")
+	if rec != nil || err != nil {
+		t.Fatalf("Start = %v, %v, want nil, nil", rec, err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("disabled recorder created its directory: %v", err)
+	}
+
+	var nilRecorder *Recorder
+	if nilRecorder.Enabled() {
+		t.Error("nil recorder is enabled")
+	}
+}
@@ -0,0 +1,114 @@
+// Package server provides the connection handling shared by the small
+// plain-TCP protocol front-ends such as Gemini, Gopher and Finger.
+package server
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds how long a single request/response exchange may take
+const DefaultTimeout = 30 * time.Second
+
+// ErrServerClosed is returned by Serve after Shutdown has been called
+var ErrServerClosed = errors.New("server: closed")
+
+// ErrLineTooLong is returned by ReadLine when a request line does not fit
+var ErrLineTooLong = errors.New("server: request line too long")
+
+// Server accepts connections and hands each one to Handler in its own
+// goroutine. The connection is closed when Handler returns.
+type Server struct {
+	// Handler serves a single connection
+	Handler func(conn net.Conn)
+
+	// Timeout is the deadline applied to each connection. Zero means
+	// DefaultTimeout.
+	Timeout time.Duration
+
+	mu       sync.Mutex
+	listener net.Listener
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// Serve accepts connections on ln until Shutdown is called
+func (s *Server) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrServerClosed
+	}
+	s.listener = ln
+	s.mu.Unlock()
+
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer conn.Close()
+
+			conn.SetDeadline(time.Now().Add(timeout))
+			s.Handler(conn)
+		}()
+	}
+}
+
+// Shutdown stops accepting connections and waits for in-flight requests
+// to finish or for ctx to expire
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReadLine reads a request line of at most max bytes, including the line
+// ending, without buffering any more than that. A longer line returns
+// ErrLineTooLong as soon as max bytes have arrived.
+func ReadLine(r io.Reader, max int) (string, error) {
+	line, err := bufio.NewReaderSize(r, max).ReadSlice('\n')
+	if errors.Is(err, bufio.ErrBufferFull) {
+		return "", ErrLineTooLong
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(line), nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// countingReader counts the bytes read from it
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestReadLine(t *testing.T) {
+	const max = 32
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "short line",
+			input: "gemini://example.org/\r\nignored",
+			want:  "gemini://example.org/\r\n",
+		},
+		{
+			name:  "line filling the limit",
+			input: strings.Repeat("a", max-2) + "\r\n",
+			want:  strings.Repeat("a", max-2) + "\r\n",
+		},
+		{
+			name:    "line one byte over the limit",
+			input:   strings.Repeat("a", max-1) + "\r\n",
+			wantErr: ErrLineTooLong,
+		},
+		{
+			name:    "endless line",
+			input:   strings.Repeat("a", 10*max),
+			wantErr: ErrLineTooLong,
+		},
+		{
+			name:    "no line ending",
+			input:   "partial",
+			wantErr: io.EOF,
+		},
+		{
+			name:    "empty input",
+			input:   "",
+			wantErr: io.EOF,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &countingReader{r: strings.NewReader(tt.input)}
+			got, err := ReadLine(r, max)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReadLine error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ReadLine = %q, want %q", got, tt.want)
+			}
+			if r.n > max {
+				t.Errorf("read %d bytes, want at most %d", r.n, max)
+			}
+		})
+	}
+}
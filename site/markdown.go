@@ -0,0 +1,88 @@
+package site
+
+import (
+	"bytes"
+
+	"github.com/alecthomas/chroma/v2"
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// codeStyle is the chroma style used for highlighted code blocks
+const codeStyle = "dracula"
+
+var (
+	codeFormatter = chromahtml.New(chromahtml.WithClasses(true))
+
+	markdown = goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(codeBlockRenderer{}, 100)),
+		),
+	)
+)
+
+// MarkdownToHTML renders markdown to HTML with syntax-highlighted code blocks
+func MarkdownToHTML(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// codeCSS returns the stylesheet for highlighted code blocks
+func codeCSS() (string, error) {
+	var buf bytes.Buffer
+	if err := codeFormatter.WriteCSS(&buf, styles.Get(codeStyle)); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// codeBlockRenderer replaces goldmark's fenced code block rendering with
+// chroma highlighting
+type codeBlockRenderer struct{}
+
+func (r codeBlockRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindFencedCodeBlock, r.renderFencedCodeBlock)
+}
+
+func (r codeBlockRenderer) renderFencedCodeBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*ast.FencedCodeBlock)
+
+	var code bytes.Buffer
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		line := lines.At(i)
+		code.Write(line.Value(source))
+	}
+
+	var lexer chroma.Lexer
+	if language := n.Language(source); language != nil {
+		lexer = lexers.Get(string(language))
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code.String())
+	if err != nil {
+		return ast.WalkStop, err
+	}
+	if err := codeFormatter.Format(w, styles.Get(codeStyle), iterator); err != nil {
+		return ast.WalkStop, err
+	}
+
+	return ast.WalkSkipChildren, nil
+}
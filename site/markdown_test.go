@@ -0,0 +1,57 @@
+package site
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     []string
+		dontWant []string
+	}{
+		{
+			name:     "fenced code is highlighted with classes",
+			markdown: "```go\nfunc main() {}\n```",
+			want:     []string{`<pre class="chroma">`, `<span class="kd">func</span>`},
+		},
+		{
+			name:     "code without a language is escaped",
+			markdown: "```\nplain <b>\n```",
+			want:     []string{`<pre class="chroma">`, "plain &lt;b&gt;"},
+			dontWant: []string{"<b>"},
+		},
+		{
+			name:     "GFM tables, strikethrough and autolinks",
+			markdown: "| a |\n|---|\n| 1 |\n\n~~gone~~ https://example.com",
+			want:     []string{"<th>a</th>", "<td>1</td>", "<del>gone</del>", `<a href="https://example.com">`},
+		},
+		{
+			name:     "raw HTML is dropped",
+			markdown: "<script>alert(1)</script>\n\nhi",
+			want:     []string{"<p>hi</p>"},
+			dontWant: []string{"<script>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := MarkdownToHTML(tt.markdown)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("missing %q in\n%s", want, html)
+				}
+			}
+			for _, dontWant := range tt.dontWant {
+				if strings.Contains(html, dontWant) {
+					t.Errorf("unexpected %q in\n%s", dontWant, html)
+				}
+			}
+		})
+	}
+}
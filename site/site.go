@@ -0,0 +1,181 @@
+// Package site renders the portfolio content to a static HTML website, so
+// the terminal UI and the web share a single source of text.
+package site
+
+import (
+	"embed"
+	"fmt"
+	"html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Arpan-206/terminal-portfolio/tui"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+// Options configures a site build
+type Options struct {
+	// OutDir is the directory the site is written to
+	OutDir string
+
+	// Base is the URL path the site is served under, such as "/" or
+	// "/portfolio/"
+	Base string
+}
+
+// navItem is a link in the site navigation bar
+type navItem struct {
+	Title  string
+	URL    string
+	Active bool
+}
+
+// pageData is passed to every template
+type pageData struct {
+	Title   string
+	Base    string
+	Nav     []navItem
+	Content template.HTML
+	Posts   []tui.BlogPost
+	Post    tui.BlogPost
+}
+
+// pageDir returns the output directory of a top-level page relative to
+// the site root. The home page is the root itself.
+func pageDir(page tui.ContentPage) string {
+	if page.ID == "home" {
+		return ""
+	}
+	return page.ID
+}
+
+// Build renders the top-level pages, the projects page, the blog index and
+// every published blog post into opts.OutDir
+func Build(opts Options) error {
+	if opts.Base == "" {
+		opts.Base = "/"
+	}
+	if !strings.HasSuffix(opts.Base, "/") {
+		opts.Base += "/"
+	}
+
+	b := &builder{opts: opts, pages: tui.LoadPages()}
+	if err := b.loadTemplates(); err != nil {
+		return err
+	}
+
+	posts := tui.LoadBlogPosts()
+
+	for _, page := range b.pages {
+		source := page.Content
+		switch page.ID {
+		case tui.BlogPageID:
+			continue
+		case tui.ProjectsPageID:
+			source = tui.ProjectsMarkdown(tui.LoadProjects(), tui.GetProfile())
+		}
+		if err := b.writeMarkdownPage(pageDir(page), page.Title, source); err != nil {
+			return err
+		}
+	}
+
+	if err := b.write("blog", b.blog, "blog", pageData{Title: "Blog", Posts: posts}); err != nil {
+		return err
+	}
+
+	for _, post := range posts {
+		content, err := MarkdownToHTML(post.Content)
+		if err != nil {
+			return fmt.Errorf("rendering post %s: %w", post.ID, err)
+		}
+		data := pageData{Title: post.Title, Post: post, Content: template.HTML(content)}
+		if err := b.write(path.Join("blog", post.ID), b.post, "blog", data); err != nil {
+			return err
+		}
+	}
+
+	return b.writeStylesheet()
+}
+
+type builder struct {
+	opts  Options
+	pages []tui.ContentPage
+	page  *template.Template
+	blog  *template.Template
+	post  *template.Template
+}
+
+func (b *builder) loadTemplates() error {
+	load := func(name string) (*template.Template, error) {
+		return template.ParseFS(templateFiles, "templates/layout.html", "templates/"+name)
+	}
+
+	var err error
+	if b.page, err = load("page.html"); err != nil {
+		return err
+	}
+	if b.blog, err = load("blog.html"); err != nil {
+		return err
+	}
+	b.post, err = load("post.html")
+	return err
+}
+
+func (b *builder) writeMarkdownPage(dir, title, source string) error {
+	content, err := MarkdownToHTML(source)
+	if err != nil {
+		return fmt.Errorf("rendering %s: %w", title, err)
+	}
+	return b.write(dir, b.page, dir, pageData{Title: title, Content: template.HTML(content)})
+}
+
+// write renders a page to dir/index.html so every page has a clean
+// permalink such as /blog/go-vs-rust-comparison/
+func (b *builder) write(dir string, tmpl *template.Template, section string, data pageData) error {
+	data.Base = b.opts.Base
+	for _, page := range b.pages {
+		if page.Hidden {
+			continue
+		}
+		dir := pageDir(page)
+		data.Nav = append(data.Nav, navItem{
+			Title:  page.Title,
+			URL:    b.opts.Base + strings.TrimPrefix(dir+"/", "/"),
+			Active: dir == section,
+		})
+	}
+
+	outDir := filepath.Join(b.opts.OutDir, filepath.FromSlash(dir))
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(filepath.Join(outDir, "index.html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return tmpl.ExecuteTemplate(f, "layout", data)
+}
+
+func (b *builder) writeStylesheet() error {
+	css, err := templateFiles.ReadFile("templates/style.css")
+	if err != nil {
+		return err
+	}
+	code, err := codeCSS()
+	if err != nil {
+		return err
+	}
+
+	assets := filepath.Join(b.opts.OutDir, "assets")
+	if err := os.MkdirAll(assets, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(assets, "style.css"), append(css, code...), 0o644)
+}
@@ -0,0 +1,393 @@
+// Package telnet serves the portfolio TUI over plain telnet for clients
+// that cannot speak SSH. Window size is negotiated with NAWS (RFC 1073) and
+// the terminal type with TTYPE (RFC 1091).
+package telnet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Arpan-206/terminal-portfolio/recorder"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Telnet command and option codes
+const (
+	cmdSE   = 240
+	cmdSB   = 250
+	cmdWILL = 251
+	cmdWONT = 252
+	cmdDO   = 253
+	cmdDONT = 254
+	cmdIAC  = 255
+
+	optECHO  = 1
+	optSGA   = 3
+	optTTYPE = 24
+	optNAWS  = 31
+
+	ttypeIS   = 0
+	ttypeSEND = 1
+)
+
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	// negotiationTimeout is how long to wait for the client to report its
+	// window size and terminal type before starting with the defaults
+	negotiationTimeout = time.Second
+
+	// maxSubnegotiation bounds a subnegotiation frame. NAWS and TTYPE need
+	// far less; longer frames are dropped.
+	maxSubnegotiation = 256
+)
+
+// ErrServerClosed is returned by Serve after Shutdown has been called
+var ErrServerClosed = errors.New("telnet: server closed")
+
+// Server accepts telnet connections and runs a tui.Model for each
+type Server struct {
+	// Recorder, when enabled, records telnet sessions alongside SSH ones
+	Recorder *recorder.Recorder
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// Serve accepts connections on ln until Shutdown is called
+func (s *Server) Serve(ln net.Listener) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrServerClosed
+	}
+	s.listener = ln
+	s.conns = make(map[net.Conn]struct{})
+	s.mu.Unlock()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return ErrServerClosed
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+		}()
+	}
+}
+
+// Shutdown stops accepting connections, disconnects active sessions and
+// waits for them to finish or for ctx to expire
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *Server) handle(conn net.Conn) {
+	defer conn.Close()
+
+	c := newSession(conn)
+	c.negotiate()
+
+	// Wait for both answers so the session starts at the right size and
+	// logs the real terminal type
+	width, height := defaultWidth, defaultHeight
+	sizes, typed := c.sizes, c.typed
+	deadline := time.After(negotiationTimeout)
+	for sizes != nil || typed != nil {
+		select {
+		case size := <-sizes:
+			width, height = size.Width, size.Height
+			sizes = nil
+		case <-typed:
+			typed = nil
+		case <-c.done:
+			return
+		case <-deadline:
+			sizes, typed = nil, nil
+		}
+	}
+
+	tui.RunStream(tui.Stream{
+		Protocol: "telnet",
+		Remote:   conn.RemoteAddr().String(),
+		Term:     c.terminalType(),
+		Width:    width,
+		Height:   height,
+		Input:    c.input,
+		Output:   c,
+		Sizes:    c.sizes,
+		Done:     c.done,
+	}, s.Recorder)
+}
+
+// session wraps a telnet connection, separating protocol negotiation from
+// the keystrokes delivered to the program
+type session struct {
+	conn  net.Conn
+	input *io.PipeReader
+	pipe  *io.PipeWriter
+	sizes chan tea.WindowSizeMsg
+	done  chan struct{}
+
+	mu    sync.Mutex
+	ttype string
+
+	// typed is closed once the client reports its terminal type or
+	// refuses to
+	typed     chan struct{}
+	typedOnce sync.Once
+
+	// parser state carried across reads
+	state      int
+	verb       byte
+	sb         []byte
+	sbOverflow bool
+	lastCR     bool
+}
+
+const (
+	stateData = iota
+	stateIAC
+	stateOption
+	stateSB
+	stateSBIAC
+)
+
+func newSession(conn net.Conn) *session {
+	r, w := io.Pipe()
+	c := &session{
+		conn:  conn,
+		input: r,
+		pipe:  w,
+		sizes: make(chan tea.WindowSizeMsg, 1),
+		done:  make(chan struct{}),
+		typed: make(chan struct{}),
+	}
+	go c.readLoop()
+	return c
+}
+
+// negotiate asks the client for its window size and terminal type, and
+// puts it into character-at-a-time mode with server-side echo
+func (c *session) negotiate() {
+	c.conn.Write([]byte{
+		cmdIAC, cmdDO, optNAWS,
+		cmdIAC, cmdDO, optTTYPE,
+		cmdIAC, cmdWILL, optECHO,
+		cmdIAC, cmdWILL, optSGA,
+		cmdIAC, cmdDO, optSGA,
+	})
+}
+
+// markTyped stops the wait for the terminal type
+func (c *session) markTyped() {
+	c.typedOnce.Do(func() { close(c.typed) })
+}
+
+func (c *session) terminalType() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttype == "" {
+		return "unknown"
+	}
+	return c.ttype
+}
+
+// Write sends program output, escaping any literal IAC bytes
+func (c *session) Write(p []byte) (int, error) {
+	if bytes.IndexByte(p, cmdIAC) >= 0 {
+		escaped := bytes.ReplaceAll(p, []byte{cmdIAC}, []byte{cmdIAC, cmdIAC})
+		if _, err := c.conn.Write(escaped); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	return c.conn.Write(p)
+}
+
+func (c *session) readLoop() {
+	defer close(c.done)
+	defer c.pipe.Close()
+
+	buf := make([]byte, 1024)
+	for {
+		n, err := c.conn.Read(buf)
+		if n > 0 {
+			// Deliver each read as one chunk so escape sequences such as
+			// arrow keys reach the program intact
+			if data := c.parse(buf[:n]); len(data) > 0 {
+				if _, err := c.pipe.Write(data); err != nil {
+					return
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// parse strips telnet commands from p and returns the remaining data
+func (c *session) parse(p []byte) []byte {
+	var data []byte
+
+	for _, b := range p {
+		switch c.state {
+		case stateData:
+			if b == cmdIAC {
+				c.state = stateIAC
+				continue
+			}
+			// Telnet sends Enter as CR NUL or CR LF; collapse it to CR
+			if c.lastCR && (b == 0 || b == '\n') {
+				c.lastCR = false
+				continue
+			}
+			c.lastCR = b == '\r'
+			data = append(data, b)
+
+		case stateIAC:
+			switch b {
+			case cmdIAC:
+				data = append(data, cmdIAC)
+				c.state = stateData
+			case cmdDO, cmdDONT, cmdWILL, cmdWONT:
+				c.verb = b
+				c.state = stateOption
+			case cmdSB:
+				c.sb = c.sb[:0]
+				c.sbOverflow = false
+				c.state = stateSB
+			default:
+				c.state = stateData
+			}
+
+		case stateOption:
+			if b == optTTYPE {
+				switch c.verb {
+				case cmdWILL:
+					c.conn.Write([]byte{cmdIAC, cmdSB, optTTYPE, ttypeSEND, cmdIAC, cmdSE})
+				case cmdWONT:
+					c.markTyped()
+				}
+			}
+			c.state = stateData
+
+		case stateSB:
+			if b == cmdIAC {
+				c.state = stateSBIAC
+				continue
+			}
+			c.appendSB(b)
+
+		case stateSBIAC:
+			switch b {
+			case cmdSE:
+				if !c.sbOverflow {
+					c.subnegotiation(c.sb)
+				}
+				c.state = stateData
+			case cmdIAC:
+				c.appendSB(cmdIAC)
+				c.state = stateSB
+			default:
+				c.state = stateSB
+			}
+		}
+	}
+
+	return data
+}
+
+// appendSB adds b to the subnegotiation frame, marking the frame to be
+// dropped once it grows past maxSubnegotiation
+func (c *session) appendSB(b byte) {
+	if len(c.sb) >= maxSubnegotiation {
+		c.sbOverflow = true
+		return
+	}
+	c.sb = append(c.sb, b)
+}
+
+func (c *session) subnegotiation(sb []byte) {
+	if len(sb) == 0 {
+		return
+	}
+
+	switch sb[0] {
+	case optNAWS:
+		if len(sb) < 5 {
+			return
+		}
+		size := tea.WindowSizeMsg{
+			Width:  int(sb[1])<<8 | int(sb[2]),
+			Height: int(sb[3])<<8 | int(sb[4]),
+		}
+		if size.Width == 0 || size.Height == 0 {
+			return
+		}
+		// Keep only the most recent size if the program is behind
+		select {
+		case <-c.sizes:
+		default:
+		}
+		c.sizes <- size
+
+	case optTTYPE:
+		if len(sb) < 2 || sb[1] != ttypeIS {
+			return
+		}
+		c.mu.Lock()
+		c.ttype = string(sb[2:])
+		c.mu.Unlock()
+		c.markTyped()
+	}
+}
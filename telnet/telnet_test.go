@@ -0,0 +1,201 @@
+package telnet
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recordingConn is a net.Conn that keeps everything written to it
+type recordingConn struct {
+	net.Conn
+	written bytes.Buffer
+}
+
+func (c *recordingConn) Write(p []byte) (int, error) {
+	return c.written.Write(p)
+}
+
+// testSession returns a session that is fed by parse rather than a read
+// loop
+func testSession() (*session, *recordingConn) {
+	conn := &recordingConn{}
+	return &session{
+		conn:  conn,
+		sizes: make(chan tea.WindowSizeMsg, 1),
+		typed: make(chan struct{}),
+	}, conn
+}
+
+func frame(parts ...[]byte) []byte {
+	return bytes.Join(parts, nil)
+}
+
+var (
+	iac      = []byte{cmdIAC}
+	sbNAWS   = []byte{cmdIAC, cmdSB, optNAWS}
+	sbTTYPE  = []byte{cmdIAC, cmdSB, optTTYPE, ttypeIS}
+	se       = []byte{cmdIAC, cmdSE}
+	willType = []byte{cmdIAC, cmdWILL, optTTYPE}
+	sendType = []byte{cmdIAC, cmdSB, optTTYPE, ttypeSEND, cmdIAC, cmdSE}
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		reads     [][]byte
+		wantData  string
+		wantSize  *tea.WindowSizeMsg
+		wantType  string
+		wantTyped bool
+		wantSent  []byte
+	}{
+		{
+			name:     "plain data",
+			reads:    [][]byte{[]byte("hello")},
+			wantData: "hello",
+		},
+		{
+			name:     "escaped IAC",
+			reads:    [][]byte{{'a', cmdIAC, cmdIAC, 'b'}},
+			wantData: "a\xffb",
+		},
+		{
+			name:     "CR NUL and CR LF collapse to CR",
+			reads:    [][]byte{[]byte("a\r\x00b\r\nc")},
+			wantData: "a\rb\rc",
+		},
+		{
+			name:     "CR NUL split across reads",
+			reads:    [][]byte{[]byte("a\r"), []byte("\x00b")},
+			wantData: "a\rb",
+		},
+		{
+			name:     "IAC at the end of a read",
+			reads:    [][]byte{[]byte("a\xff"), {cmdWILL, optTTYPE, 'b'}},
+			wantData: "ab",
+			wantSent: sendType,
+		},
+		{
+			name:     "escaped IAC split across reads",
+			reads:    [][]byte{{'a', cmdIAC}, {cmdIAC, 'b'}},
+			wantData: "a\xffb",
+		},
+		{
+			name:     "NAWS",
+			reads:    [][]byte{frame(sbNAWS, []byte{0, 120, 0, 40}, se)},
+			wantSize: &tea.WindowSizeMsg{Width: 120, Height: 40},
+		},
+		{
+			name:     "NAWS split inside IAC SE",
+			reads:    [][]byte{frame(sbNAWS, []byte{0, 120, 0, 40}, iac), {cmdSE, 'x'}},
+			wantData: "x",
+			wantSize: &tea.WindowSizeMsg{Width: 120, Height: 40},
+		},
+		{
+			name:     "NAWS with an escaped 255",
+			reads:    [][]byte{frame(sbNAWS, []byte{0, cmdIAC, cmdIAC, 0, 24}, se)},
+			wantSize: &tea.WindowSizeMsg{Width: 255, Height: 24},
+		},
+		{
+			name:  "NAWS with a zero size is ignored",
+			reads: [][]byte{frame(sbNAWS, []byte{0, 0, 0, 24}, se)},
+		},
+		{
+			name:  "unterminated subnegotiation swallows what follows",
+			reads: [][]byte{frame(sbNAWS, []byte{0, 80, 0, 24}), []byte("abc")},
+		},
+		{
+			name: "oversized subnegotiation is dropped",
+			reads: [][]byte{
+				frame(sbTTYPE, bytes.Repeat([]byte("x"), maxSubnegotiation)),
+				frame([]byte("yy"), se, []byte("ok")),
+			},
+			wantData: "ok",
+		},
+		{
+			name:      "terminal type",
+			reads:     [][]byte{willType, frame(sbTTYPE, []byte("xterm-256color"), se)},
+			wantType:  "xterm-256color",
+			wantTyped: true,
+			wantSent:  sendType,
+		},
+		{
+			name:      "terminal type refused",
+			reads:     [][]byte{{cmdIAC, cmdWONT, optTTYPE}},
+			wantTyped: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, conn := testSession()
+
+			var data []byte
+			for _, read := range tt.reads {
+				data = append(data, c.parse(read)...)
+			}
+			if string(data) != tt.wantData {
+				t.Errorf("data = %q, want %q", data, tt.wantData)
+			}
+
+			select {
+			case size := <-c.sizes:
+				if tt.wantSize == nil || size != *tt.wantSize {
+					t.Errorf("size = %v, want %v", size, tt.wantSize)
+				}
+			default:
+				if tt.wantSize != nil {
+					t.Errorf("no size, want %v", *tt.wantSize)
+				}
+			}
+
+			wantType := tt.wantType
+			if wantType == "" {
+				wantType = "unknown"
+			}
+			if got := c.terminalType(); got != wantType {
+				t.Errorf("terminal type = %q, want %q", got, wantType)
+			}
+
+			select {
+			case <-c.typed:
+				if !tt.wantTyped {
+					t.Error("terminal type wait ended early")
+				}
+			default:
+				if tt.wantTyped {
+					t.Error("terminal type wait did not end")
+				}
+			}
+
+			if !bytes.Equal(conn.written.Bytes(), tt.wantSent) {
+				t.Errorf("sent % x, want % x", conn.written.Bytes(), tt.wantSent)
+			}
+		})
+	}
+}
+
+func TestWriteEscapesIAC(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "plain", want: "plain"},
+		{in: "a\xffb", want: "a\xff\xffb"},
+		{in: strings.Repeat("\xff", 3), want: strings.Repeat("\xff", 6)},
+	}
+
+	for _, tt := range tests {
+		c, conn := testSession()
+		n, err := c.Write([]byte(tt.in))
+		if err != nil || n != len(tt.in) {
+			t.Errorf("Write(%q) = %d, %v, want %d, nil", tt.in, n, err, len(tt.in))
+		}
+		if got := conn.written.String(); got != tt.want {
+			t.Errorf("Write(%q) sent %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
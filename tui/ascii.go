@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+	"strings"
+)
+
+// asciiRamp orders characters from lightest to darkest coverage
+const asciiRamp = " .:-=+*#%@"
+
+// ImageToASCII renders the image at path as ASCII art width characters
+// wide. Terminal cells are about twice as tall as they are wide, so each
+// character covers a block twice as high as it is wide.
+func ImageToASCII(path string, width int) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return "", fmt.Errorf("%s: empty image", path)
+	}
+	width = min(width, bounds.Dx())
+	height := max(1, width*bounds.Dy()/bounds.Dx()/2)
+
+	var b strings.Builder
+	for row := 0; row < height; row++ {
+		y0 := bounds.Min.Y + row*bounds.Dy()/height
+		y1 := bounds.Min.Y + (row+1)*bounds.Dy()/height
+
+		for col := 0; col < width; col++ {
+			x0 := bounds.Min.X + col*bounds.Dx()/width
+			x1 := bounds.Min.X + (col+1)*bounds.Dx()/width
+
+			// Average the luminance over the block this character covers
+			var sum, n float64
+			for y := y0; y < max(y1, y0+1); y++ {
+				for x := x0; x < max(x1, x0+1); x++ {
+					r, g, bl, _ := img.At(x, y).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(bl)
+					n++
+				}
+			}
+			luminance := sum / n / 0xffff
+
+			// Dark pixels get dense characters
+			i := int((1 - luminance) * float64(len(asciiRamp)-1))
+			b.WriteByte(asciiRamp[max(0, min(len(asciiRamp)-1, i))])
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,171 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// blogPage is the Blog section: the list of posts, the tags and reading
+// list views, and the post reader. Its state lives on the Model, which the
+// reader shares with search, deep links and visitor tracking.
+type blogPage struct{}
+
+func (blogPage) Title() string {
+	return "Blog"
+}
+
+func (blogPage) View(m *Model) string {
+	switch {
+	case m.viewingBlogEntry:
+		return highlightMatches(m.getBlogEntryContent(), m.findMatches, m.findCurrent)
+	case m.viewingTags:
+		return m.getTagsContent()
+	case m.viewingSaved:
+		return m.getReadingListContent()
+	default:
+		return m.getBlogContent()
+	}
+}
+
+func (blogPage) Update(m *Model, k string) (bool, tea.Cmd) {
+	switch {
+	case m.viewingBlogEntry:
+		return m.updateReader(k)
+	case m.viewingTags:
+		return m.updateTags(k) || m.pageNavKey(k), nil
+	case m.viewingSaved:
+		return m.updateReadingList(k) || m.pageNavKey(k), nil
+	}
+
+	switch {
+	case KeyMatches(k, m.keys.Up):
+		m.moveBlogSelection(-1)
+
+	case KeyMatches(k, m.keys.Down):
+		m.moveBlogSelection(1)
+
+	case KeyMatches(k, m.keys.Top):
+		m.moveBlogSelection(-len(m.blogEntries))
+
+	case KeyMatches(k, m.keys.Bottom):
+		m.moveBlogSelection(len(m.blogEntries))
+		m.updateViewportContent()
+		m.viewport.GotoBottom()
+		return true, nil
+
+	case KeyMatches(k, m.keys.Tags):
+		m.viewingTags = true
+
+	case KeyMatches(k, m.keys.Bookmark):
+		if len(m.visibleBlogEntries()) == 0 {
+			return true, nil
+		}
+		m.toggleBookmark(m.selectedBlogEntry)
+
+	case KeyMatches(k, m.keys.ReadingList):
+		m.viewingSaved = true
+		m.selectedSaved = 0
+
+	case KeyMatches(k, m.keys.ClearFilter):
+		if m.tagFilter == "" {
+			return true, nil
+		}
+		m.setTagFilter("")
+
+	case KeyMatches(k, m.keys.Select):
+		if len(m.visibleBlogEntries()) > 0 {
+			m.viewingBlogEntry = true
+			m.logPostOpen()
+			m.updateViewportContent()
+			m.restorePosition()
+		}
+		return true, nil
+
+	default:
+		return false, nil
+	}
+
+	m.updateViewportContent()
+	return true, nil
+}
+
+// updateReader handles keys while a post is open, reporting whether the
+// key was used
+func (m *Model) updateReader(k string) (bool, tea.Cmd) {
+	if m.tocFocused && m.updateTOC(k) {
+		return true, nil
+	}
+
+	if len(m.findMatches) > 0 {
+		switch {
+		case KeyMatches(k, m.keys.NextMatch):
+			m.nextMatch(false)
+			return true, nil
+		case KeyMatches(k, m.keys.PrevMatch):
+			m.nextMatch(true)
+			return true, nil
+		case KeyMatches(k, m.keys.Cancel):
+			m.clearFind()
+			m.refreshFind()
+			return true, nil
+		}
+	}
+
+	switch {
+	case KeyMatches(k, m.keys.Find):
+		return true, m.openFind()
+
+	case KeyMatches(k, m.keys.Contents):
+		m.toggleTOC()
+
+	case KeyMatches(k, m.keys.Bookmark):
+		m.toggleBookmark(m.selectedBlogEntry)
+
+	case KeyMatches(k, m.keys.Back):
+		m.viewingBlogEntry = false
+		m.clearFind()
+		m.tocOpen = false
+		m.tocFocused = false
+		m.viewport.Width = m.readerWidth()
+		m.updateViewportContent()
+
+	default:
+		return m.pageNavKey(k), nil
+	}
+	return true, nil
+}
+
+func (blogPage) Help(m *Model) (string, []key.Binding) {
+	keys := m.keys
+	choose := pairBinding(keys.Up, keys.Down, "choose")
+	changePage := pairBinding(keys.PrevPage, keys.NextPage, "change page")
+
+	switch {
+	case m.finding:
+		status := m.findInput.View()
+		if found := m.findStatus(); found != "" {
+			status += "  " + found
+		}
+		return status, []key.Binding{withDesc(keys.Select, "keep"), keys.Cancel}
+	case m.tocFocused:
+		return "📑 Contents", []key.Binding{choose, withDesc(keys.Select, "jump"), withDesc(keys.Cancel, "back to post"), withDesc(keys.Contents, "close")}
+	case m.viewingBlogEntry && len(m.findMatches) > 0:
+		return fmt.Sprintf("📖 Reading blog post • /%s %s", m.findInput.Value(), m.findStatus()),
+			[]key.Binding{pairBinding(keys.NextMatch, keys.PrevMatch, "next/prev"), withDesc(keys.Cancel, "clear"), withDesc(keys.Back, "return")}
+	case m.viewingBlogEntry:
+		return "📖 " + m.readingProgress() + " • " + m.readingStatus(),
+			[]key.Binding{keys.Find, keys.Contents, keys.Bookmark, withDesc(keys.Back, "return"), keys.Quit}
+	case m.viewingSaved:
+		return "🔖 Reading list", []key.Binding{choose, withDesc(keys.Select, "read"), withDesc(keys.Bookmark, "remove"), withDesc(keys.Back, "return"), keys.Quit}
+	case m.viewingTags:
+		return "🏷️  Tags", []key.Binding{choose, withDesc(keys.Select, "filter posts"), withDesc(keys.Back, "return"), keys.Quit}
+	case m.tagFilter != "":
+		return "📚 Filtered by " + m.tagFilterChip(),
+			[]key.Binding{withDesc(keys.ClearFilter, "clear"), pairBinding(keys.Up, keys.Down, "navigate"), withDesc(keys.Select, "read"), keys.Tags, changePage}
+	default:
+		return "📚 Blog posts",
+			[]key.Binding{pairBinding(keys.Up, keys.Down, "navigate"), withDesc(keys.Select, "read"), keys.Bookmark, keys.ReadingList, keys.Tags, keys.Search, changePage, keys.Quit}
+	}
+}
@@ -8,8 +8,11 @@ import (
 	"sort"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/log"
 )
 
 // FrontMatter represents the YAML frontmatter in markdown files
@@ -32,6 +35,7 @@ type BlogPost struct {
 	Date        time.Time
 	Tags        []string
 	ReadTime    string
+	WordCount   int
 	Author      string
 	Published   bool
 	FilePath    string
@@ -41,13 +45,45 @@ type BlogPost struct {
 func parseFrontMatter(content string) (FrontMatter, string, error) {
 	var fm FrontMatter
 	
+	fields, markdownContent, err := frontMatterFields(content)
+	if err != nil {
+		return fm, content, err
+	}
+	
+	for key, value := range fields {
+		switch key {
+		case "title":
+			fm.Title = value
+		case "summary":
+			fm.Summary = value
+		case "date":
+			fm.Date = value
+		case "readTime":
+			fm.ReadTime = value
+		case "author":
+			fm.Author = value
+		case "published":
+			fm.Published = value == "true"
+		case "tags":
+			fm.Tags = parseList(value)
+		}
+	}
+	
+	return fm, markdownContent, nil
+}
+
+// frontMatterFields splits markdown into its frontmatter keys and values
+// and the content that follows. Content without frontmatter has no fields.
+func frontMatterFields(content string) (map[string]string, string, error) {
+	fields := make(map[string]string)
+	
 	if !strings.HasPrefix(content, "---\n") {
-		return fm, content, nil
+		return fields, content, nil
 	}
 	
 	parts := strings.SplitN(content, "---\n", 3)
 	if len(parts) < 3 {
-		return fm, content, fmt.Errorf("invalid frontmatter format")
+		return fields, content, fmt.Errorf("invalid frontmatter format")
 	}
 	
 	frontmatterContent := parts[1]
@@ -68,35 +104,25 @@ func parseFrontMatter(content string) (FrontMatter, string, error) {
 		
 		key := strings.TrimSpace(parts[0])
 		value := strings.TrimSpace(parts[1])
-		value = strings.Trim(value, `"`)
-		
-		switch key {
-		case "title":
-			fm.Title = value
-		case "summary":
-			fm.Summary = value
-		case "date":
-			fm.Date = value
-		case "readTime":
-			fm.ReadTime = value
-		case "author":
-			fm.Author = value
-		case "published":
-			fm.Published = value == "true"
-		case "tags":
-			// Parse tags array - simple implementation
-			value = strings.Trim(value, "[]")
-			if value != "" {
-				tags := strings.Split(value, ",")
-				for i, tag := range tags {
-					tags[i] = strings.Trim(strings.TrimSpace(tag), `"`)
-				}
-				fm.Tags = tags
-			}
-		}
+		fields[key] = strings.Trim(value, `"`)
 	}
 	
-	return fm, markdownContent, nil
+	return fields, markdownContent, nil
+}
+
+// parseList parses a frontmatter array such as ["go", "tui"]
+func parseList(value string) []string {
+	// Parse array - simple implementation
+	value = strings.Trim(value, "[]")
+	if value == "" {
+		return nil
+	}
+	
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		items[i] = strings.Trim(strings.TrimSpace(item), `"`)
+	}
+	return items
 }
 
 // readMarkdownFile reads and parses a markdown file
@@ -111,17 +137,19 @@ func readMarkdownFile(filePath string) (BlogPost, error) {
 		return BlogPost{}, err
 	}
 	
-	// Parse date
+	// Parse date. Published posts must have one so they keep a stable
+	// position and feeds never report them as newly published
 	date, err := time.Parse("2006-01-02", fm.Date)
-	if err != nil {
-		date = time.Now()
+	if err != nil && fm.Published {
+		return BlogPost{}, fmt.Errorf("%s: invalid or missing date %q: %w", filePath, fm.Date, err)
 	}
 	
 	// Generate ID from filename
 	filename := filepath.Base(filePath)
 	id := strings.TrimSuffix(filename, filepath.Ext(filename))
 	
-	return BlogPost{
+	// Reading time is computed from the body unless the frontmatter sets it
+	return withReadingStats(BlogPost{
 		ID:        id,
 		Title:     fm.Title,
 		Summary:   fm.Summary,
@@ -132,11 +160,12 @@ func readMarkdownFile(filePath string) (BlogPost, error) {
 		Author:    fm.Author,
 		Published: fm.Published,
 		FilePath:  filePath,
-	}, nil
+	}), nil
 }
 
-// GetBlogPosts returns all available blog posts from markdown files
-func GetBlogPosts() []BlogEntry {
+// LoadBlogPosts returns all published blog posts from markdown files,
+// newest first, falling back to the built-in posts when none can be read
+func LoadBlogPosts() []BlogPost {
 	var posts []BlogPost
 	
 	// Get the current working directory
@@ -169,6 +198,7 @@ func GetBlogPosts() []BlogEntry {
 			filePath := filepath.Join(blogDir, file.Name())
 			post, err := readMarkdownFile(filePath)
 			if err != nil {
+				log.Warn("Skipping blog post", "error", err)
 				continue // Skip files that can't be parsed
 			}
 			
@@ -183,23 +213,32 @@ func GetBlogPosts() []BlogEntry {
 		return posts[i].Date.After(posts[j].Date)
 	})
 	
-	// Convert to BlogEntry format for compatibility
-	var entries []BlogEntry
-	for _, post := range posts {
-		entries = append(entries, BlogEntry{
-			Title:   post.Title,
-			Summary: post.Summary,
-			Content: post.Content,
-			Date:    post.Date.Format("2006-01-02"),
-		})
-	}
-	
 	// If no posts were found, return fallback
-	if len(entries) == 0 {
+	if len(posts) == 0 {
 		return getFallbackBlogPosts()
 	}
 	
-	return entries
+	return posts
+}
+
+// DateString returns the publication date as shown in the UI
+func (p BlogPost) DateString() string {
+	return p.Date.Format("2006-01-02")
+}
+
+// Byline describes when, by whom and how long a read the post is
+func (p BlogPost) Byline() string {
+	parts := []string{"📅 " + p.DateString()}
+	if p.Author != "" {
+		parts = append(parts, "✍️  "+p.Author)
+	}
+	if p.ReadTime != "" {
+		parts = append(parts, "⏱️  "+p.ReadTime)
+	}
+	if p.WordCount > 0 {
+		parts = append(parts, formatCount(p.WordCount)+" words")
+	}
+	return strings.Join(parts, " • ")
 }
 
 // renderMarkdown renders markdown content using Glamour
@@ -234,8 +273,45 @@ func renderMarkdownForDisplay(content string, width int) string {
 	return renderMarkdown(content, displayWidth)
 }
 
+// RenderPlainText renders markdown as plain ASCII text without colours or
+// escape sequences, for text-only protocols such as Gopher
+func RenderPlainText(content string, width int) string {
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(styles.AsciiStyle),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return content
+	}
+	
+	rendered, err := r.Render(content)
+	if err != nil {
+		return content
+	}
+	
+	return rendered
+}
+
+// Slugify turns a title into a lowercase, hyphen-separated identifier
+// suitable for URLs and selectors
+func Slugify(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+			hyphen = false
+		case !hyphen && b.Len() > 0:
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
 // getFallbackBlogPosts returns hardcoded blog posts as fallback
-func getFallbackBlogPosts() []BlogEntry {
+func getFallbackBlogPosts() []BlogPost {
 	fallbackContent1 := `# Building Terminal UIs with Bubble Tea
 
 Bubble Tea is an amazing framework for building terminal user interfaces in Go. It follows the Elm architecture pattern, making it easy to reason about state management and updates.
@@ -304,88 +380,41 @@ Both Go and Rust have gained significant traction in the systems programming spa
 
 This is a fallback version of the blog post. Please check that your markdown files are properly configured in the ` + "`content/blog`" + ` directory.`
 
-	return []BlogEntry{
+	posts := []BlogPost{
 		{
-			Title:   "Building Terminal UIs with Bubble Tea",
-			Summary: "A deep dive into creating beautiful terminal applications using Charm's Bubble Tea framework.",
-			Content: fallbackContent1,
-			Date:    "2024-01-15",
+			ID:        "building-terminal-uis-with-bubble-tea",
+			Title:     "Building Terminal UIs with Bubble Tea",
+			Summary:   "A deep dive into creating beautiful terminal applications using Charm's Bubble Tea framework.",
+			Content:   fallbackContent1,
+			Date:      time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+			Tags:      []string{"go", "tui", "bubble-tea", "terminal"},
+			Author:    "Arpan Pandey",
+			Published: true,
 		},
 		{
-			Title:   "Modern Web Development Trends",
-			Summary: "Exploring the latest trends and technologies shaping web development in 2024.",
-			Content: fallbackContent2,
-			Date:    "2024-01-10",
+			ID:        "modern-web-development-trends-2024",
+			Title:     "Modern Web Development Trends",
+			Summary:   "Exploring the latest trends and technologies shaping web development in 2024.",
+			Content:   fallbackContent2,
+			Date:      time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+			Tags:      []string{"web", "javascript", "react", "trends"},
+			Author:    "Arpan Pandey",
+			Published: true,
 		},
 		{
-			Title:   "Go vs Rust: A Developer's Perspective",
-			Summary: "Comparing two modern systems programming languages from a practical standpoint.",
-			Content: fallbackContent3,
-			Date:    "2024-01-05",
+			ID:        "go-vs-rust-comparison",
+			Title:     "Go vs Rust: A Developer's Perspective",
+			Summary:   "Comparing two modern systems programming languages from a practical standpoint.",
+			Content:   fallbackContent3,
+			Date:      time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			Tags:      []string{"go", "rust", "systems", "programming"},
+			Author:    "Arpan Pandey",
+			Published: true,
 		},
 	}
-}
 
-// GetFeaturedProjects returns featured project information
-func GetFeaturedProjects() []Project {
-	return []Project{
-		{
-			Name:        "Terminal Portfolio",
-			Description: "A beautiful terminal-based portfolio built with Bubble Tea",
-			Tech:        []string{"Go", "Bubble Tea", "Lipgloss", "SSH"},
-			Features:    []string{"Responsive design", "Keyboard navigation", "Smooth scrolling", "SSH server integration"},
-			Status:      "Active",
-			URL:         "https://github.com/Arpan-206/terminal-portfolio",
-		},
-		{
-			Name:        "Full-Stack Web Applications",
-			Description: "Modern web applications using cutting-edge frameworks",
-			Tech:        []string{"React", "Next.js", "Node.js", "PostgreSQL"},
-			Features:    []string{"Real-time updates", "Responsive UI", "RESTful APIs", "Authentication"},
-			Status:      "Active",
-			URL:         "https://github.com/Arpan-206",
-		},
-		{
-			Name:        "DevOps Automation Tools",
-			Description: "Scripts and tools for automating development workflows",
-			Tech:        []string{"Python", "Bash", "Docker", "GitHub Actions"},
-			Features:    []string{"CI/CD pipelines", "Automated testing", "Deployment scripts", "Infrastructure as Code"},
-			Status:      "Active",
-			URL:         "https://github.com/Arpan-206",
-		},
-		{
-			Name:        "Machine Learning Projects",
-			Description: "AI/ML applications solving real-world problems",
-			Tech:        []string{"Python", "TensorFlow", "PyTorch", "scikit-learn"},
-			Features:    []string{"Natural language processing", "Computer vision", "Data analysis", "Model deployment"},
-			Status:      "Active",
-			URL:         "https://github.com/Arpan-206",
-		},
-		{
-			Name:        "Mobile Applications",
-			Description: "Cross-platform mobile apps with native performance",
-			Tech:        []string{"React Native", "Flutter", "Firebase", "SQLite"},
-			Features:    []string{"Offline-first", "Real-time sync", "Push notifications", "Cross-platform UI"},
-			Status:      "Active",
-			URL:         "https://github.com/Arpan-206",
-		},
-		{
-			Name:        "Open Source Contributions",
-			Description: "Contributing to various open source projects in the community",
-			Tech:        []string{"Go", "JavaScript", "Python", "Rust"},
-			Features:    []string{"Bug fixes", "New features", "Documentation", "Community support"},
-			Status:      "Ongoing",
-			URL:         "https://github.com/Arpan-206",
-		},
+	for i := range posts {
+		posts[i] = withReadingStats(posts[i])
 	}
+	return posts
 }
-
-// Project represents a project entry
-type Project struct {
-	Name        string
-	Description string
-	Tech        []string
-	Features    []string
-	Status      string
-	URL         string
-}
\ No newline at end of file
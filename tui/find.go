@@ -0,0 +1,335 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SGR sequences for find-in-page matches. They are written straight into
+// the Glamour output, which is already ANSI-styled.
+const (
+	findMatchSGR   = "\x1b[30;43m" // black on yellow
+	findCurrentSGR = "\x1b[30;45m" // black on magenta
+	sgrReset       = "\x1b[0m"
+)
+
+// findMatch is the position of a match in the rendered content, counted in
+// runes of visible text on a line
+type findMatch struct {
+	line  int
+	start int
+	end   int
+}
+
+// renderCache keeps the last post or project rendered so redraws, such as
+// finding text on every keystroke, do not run Glamour each time. It is
+// shared by copies of the Model and only holds one item at one width.
+type renderCache struct {
+	index   int
+	width   int
+	content string
+	ok      bool
+}
+
+func (r *renderCache) get(index, width int) (string, bool) {
+	if !r.ok || r.index != index || r.width != width {
+		return "", false
+	}
+	return r.content, true
+}
+
+func (r *renderCache) set(index, width int, content string) {
+	*r = renderCache{index: index, width: width, content: content, ok: true}
+}
+
+// ansiLine is one line of styled output split into visible runes, each with
+// the byte offset where it starts
+type ansiLine struct {
+	text    string
+	runes   []rune
+	offsets []int
+}
+
+func parseANSILine(line string) ansiLine {
+	l := ansiLine{text: line}
+	for i := 0; i < len(line); {
+		if n := escapeLen(line[i:]); n > 0 {
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(line[i:])
+		l.runes = append(l.runes, r)
+		l.offsets = append(l.offsets, i)
+		i += size
+	}
+	return l
+}
+
+// escapeLen returns the length of the escape sequence at the start of s, or
+// zero if s does not start with one
+func escapeLen(s string) int {
+	if len(s) < 2 || s[0] != '\x1b' {
+		return 0
+	}
+
+	switch s[1] {
+	case '[':
+		// CSI: parameters and intermediates, then a final byte in @–~
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7e {
+				return i + 1
+			}
+		}
+		return len(s)
+
+	case ']':
+		// OSC, used for hyperlinks: ends with BEL or ESC \
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == '\x1b' && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(s)
+	}
+
+	return 2
+}
+
+// findMatches returns every occurrence of query in the visible text of
+// content. Matching ignores case unless the query contains an upper-case
+// letter, as with vim's smartcase.
+func findMatches(content, query string) []findMatch {
+	if query == "" {
+		return nil
+	}
+
+	fold := !strings.ContainsFunc(query, unicode.IsUpper)
+	needle := []rune(query)
+	if fold {
+		needle = []rune(strings.ToLower(query))
+	}
+
+	var matches []findMatch
+	for n, line := range strings.Split(content, "\n") {
+		runes := parseANSILine(line).runes
+		for i := 0; i+len(needle) <= len(runes); {
+			if runesEqual(runes[i:i+len(needle)], needle, fold) {
+				matches = append(matches, findMatch{line: n, start: i, end: i + len(needle)})
+				i += len(needle)
+				continue
+			}
+			i++
+		}
+	}
+	return matches
+}
+
+func runesEqual(a, b []rune, fold bool) bool {
+	for i := range a {
+		r := a[i]
+		if fold {
+			r = unicode.ToLower(r)
+		}
+		if r != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// highlightMatches wraps each match in content with a highlight, leaving
+// the existing escape sequences intact. Styling active before a match is
+// restored after it, since the highlight has to end with a reset.
+func highlightMatches(content string, matches []findMatch, current int) string {
+	if len(matches) == 0 {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	byLine := make(map[int][]int)
+	for i, match := range matches {
+		byLine[match.line] = append(byLine[match.line], i)
+	}
+
+	for n, indexes := range byLine {
+		if n >= len(lines) {
+			continue
+		}
+		l := parseANSILine(lines[n])
+
+		var b strings.Builder
+		var styles strings.Builder // every SGR seen so far on this line
+		pos := 0
+		for _, i := range indexes {
+			match := matches[i]
+			if match.end > len(l.runes) {
+				continue
+			}
+			sgr := findMatchSGR
+			if i == current {
+				sgr = findCurrentSGR
+			}
+
+			start := l.offsets[match.start]
+			end := len(l.text)
+			if match.end < len(l.runes) {
+				end = l.offsets[match.end]
+			}
+
+			copyStyled(&b, &styles, l.text[pos:start], "")
+			b.WriteString(sgr)
+			copyStyled(&b, &styles, l.text[start:end], sgr)
+			b.WriteString(sgrReset)
+			b.WriteString(styles.String())
+			pos = end
+		}
+		b.WriteString(l.text[pos:])
+		lines[n] = b.String()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// copyStyled writes s to b, recording SGR sequences in styles. When
+// reassert is set it is written again after each SGR so styling inside a
+// match cannot override the highlight.
+func copyStyled(b, styles *strings.Builder, s, reassert string) {
+	for i := 0; i < len(s); {
+		n := escapeLen(s[i:])
+		if n == 0 {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+
+		seq := s[i : i+n]
+		b.WriteString(seq)
+		if strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m") {
+			styles.WriteString(seq)
+			if reassert != "" {
+				b.WriteString(reassert)
+			}
+		}
+		i += n
+	}
+}
+
+// openFind starts a find-in-page prompt for the open post
+func (m *Model) openFind() tea.Cmd {
+	m.finding = true
+	m.findInput.SetValue("")
+	m.findFrom = m.viewport.YOffset
+	m.findMatches = nil
+	m.findCurrent = 0
+	m.refreshFind()
+	return m.findInput.Focus()
+}
+
+// clearFind drops the query and its highlights
+func (m *Model) clearFind() {
+	m.finding = false
+	m.findInput.Blur()
+	m.findInput.SetValue("")
+	m.findMatches = nil
+	m.findCurrent = 0
+}
+
+// updateFind handles keys while the find prompt is open
+func (m Model) updateFind(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.clearFind()
+		m.refreshFind()
+		m.viewport.SetYOffset(m.findFrom)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Select):
+		m.finding = false
+		m.findInput.Blur()
+		if len(m.findMatches) == 0 {
+			m.clearFind()
+		}
+		m.refreshFind()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	previous := m.findInput.Value()
+	m.findInput, cmd = m.findInput.Update(msg)
+	if m.findInput.Value() != previous {
+		m.runFind()
+	}
+	return m, cmd
+}
+
+// runFind searches the rendered post and moves to the first match at or
+// below where the search started
+func (m *Model) runFind() {
+	m.findMatches = findMatches(m.getBlogEntryContent(), m.findInput.Value())
+	m.findCurrent = 0
+	for i, match := range m.findMatches {
+		if match.line >= m.findFrom {
+			m.findCurrent = i
+			break
+		}
+	}
+
+	m.refreshFind()
+	if len(m.findMatches) == 0 {
+		m.viewport.SetYOffset(m.findFrom)
+		return
+	}
+	m.scrollToMatch()
+}
+
+// nextMatch moves to the next match, or the previous one when backwards
+// is set, wrapping around the post
+func (m *Model) nextMatch(backwards bool) {
+	if len(m.findMatches) == 0 {
+		return
+	}
+	if backwards {
+		m.findCurrent = (m.findCurrent - 1 + len(m.findMatches)) % len(m.findMatches)
+	} else {
+		m.findCurrent = (m.findCurrent + 1) % len(m.findMatches)
+	}
+	m.refreshFind()
+	m.scrollToMatch()
+}
+
+// refreshFind redraws the post without moving the viewport
+func (m *Model) refreshFind() {
+	offset := m.viewport.YOffset
+	content := m.getPageContent()
+	m.viewport.SetContent(content)
+	m.indexHeadings(content)
+	m.viewport.SetYOffset(offset)
+}
+
+// scrollToMatch brings the current match into view
+func (m *Model) scrollToMatch() {
+	line := m.findMatches[m.findCurrent].line
+	if line < m.viewport.YOffset || line >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(line - m.viewport.Height/3)
+	}
+}
+
+// findStatus describes the find state for the footer
+func (m Model) findStatus() string {
+	if len(m.findMatches) == 0 {
+		if m.findInput.Value() == "" {
+			return ""
+		}
+		return "no matches"
+	}
+	return fmt.Sprintf("match %d/%d", m.findCurrent+1, len(m.findMatches))
+}
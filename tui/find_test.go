@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestFindMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		query   string
+		want    []findMatch
+	}{
+		{
+			name:    "empty query",
+			content: "anything",
+			query:   "",
+			want:    nil,
+		},
+		{
+			name:    "inside styled text",
+			content: "\x1b[1mhello world\x1b[0m",
+			query:   "world",
+			want:    []findMatch{{line: 0, start: 6, end: 11}},
+		},
+		{
+			name:    "split across escape codes",
+			content: "wo\x1b[31mr\x1b[0mld",
+			query:   "world",
+			want:    []findMatch{{line: 0, start: 0, end: 5}},
+		},
+		{
+			name:    "hyperlink escape is skipped",
+			content: "\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\",
+			query:   "link",
+			want:    []findMatch{{line: 0, start: 0, end: 4}},
+		},
+		{
+			name:    "wide runes",
+			content: "日本語のテキスト",
+			query:   "テキ",
+			want:    []findMatch{{line: 0, start: 4, end: 6}},
+		},
+		{
+			name:    "lower-case query ignores case",
+			content: "Go go\nGO",
+			query:   "go",
+			want:    []findMatch{{line: 0, start: 0, end: 2}, {line: 0, start: 3, end: 5}, {line: 1, start: 0, end: 2}},
+		},
+		{
+			name:    "upper-case query matches case",
+			content: "Go go\nGO",
+			query:   "Go",
+			want:    []findMatch{{line: 0, start: 0, end: 2}},
+		},
+		{
+			name:    "matches do not overlap",
+			content: "aaaa",
+			query:   "aa",
+			want:    []findMatch{{line: 0, start: 0, end: 2}, {line: 0, start: 2, end: 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := findMatches(tt.content, tt.query); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findMatches(%q, %q) = %v, want %v", tt.content, tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		query   string
+		current int
+		want    string
+	}{
+		{
+			name:    "styling is restored after the match",
+			content: "\x1b[1mhello world\x1b[0m",
+			query:   "hello",
+			current: 0,
+			want:    "\x1b[1m" + findCurrentSGR + "hello" + sgrReset + "\x1b[1m world\x1b[0m",
+		},
+		{
+			name:    "styling inside the match is overridden",
+			content: "wo\x1b[31mrld",
+			query:   "world",
+			current: -1,
+			want:    findMatchSGR + "wo\x1b[31m" + findMatchSGR + "rld" + sgrReset + "\x1b[31m",
+		},
+		{
+			name:    "wide runes",
+			content: "日本語のテキスト",
+			query:   "テキ",
+			current: 0,
+			want:    "日本語の" + findCurrentSGR + "テキ" + sgrReset + "スト",
+		},
+		{
+			name:    "current match is marked apart from the others",
+			content: "a b a",
+			query:   "a",
+			current: 1,
+			want:    findMatchSGR + "a" + sgrReset + " b " + findCurrentSGR + "a" + sgrReset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches := findMatches(tt.content, tt.query)
+			if got := highlightMatches(tt.content, matches, tt.current); got != tt.want {
+				t.Errorf("highlightMatches(%q) = %q, want %q", tt.content, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindNextMatchWraps(t *testing.T) {
+	tests := []struct {
+		name  string
+		keys  []string
+		start int
+		want  int
+	}{
+		{name: "n moves forward", keys: []string{"n"}, start: 0, want: 1},
+		{name: "n wraps to the first match", keys: []string{"n"}, start: 2, want: 0},
+		{name: "N moves back", keys: []string{"N"}, start: 2, want: 1},
+		{name: "N wraps to the last match", keys: []string{"N"}, start: 0, want: 2},
+		{name: "round trip", keys: []string{"n", "n", "n", "N"}, start: 1, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewModel(100, 30, log.New(io.Discard))
+			m.viewingBlogEntry = true
+			m.findMatches = []findMatch{{line: 1}, {line: 20}, {line: 40}}
+			m.findCurrent = tt.start
+
+			for _, k := range tt.keys {
+				if used, _ := m.updateReader(k); !used {
+					t.Fatalf("key %q was not used", k)
+				}
+			}
+			if m.findCurrent != tt.want {
+				t.Errorf("current match = %d, want %d", m.findCurrent, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// helpColumnGap separates the groups placed side by side in the overlay
+const helpColumnGap = 4
+
+var (
+	helpOverlayStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(lipgloss.Color("#7D56F4")).
+				Padding(1, 2)
+
+	helpTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#7D56F4"))
+
+	helpKeyStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#F25D94"))
+
+	helpDescStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#A0A0A0"))
+)
+
+// openHelp shows every key binding over the current page
+func (m *Model) openHelp() {
+	m.showingHelp = true
+	m.helpOffset = 0
+}
+
+// updateHelp handles keys while the help overlay is open. Keys it does not
+// use are dropped so the page underneath stays as it was.
+func (m *Model) updateHelp(k string) {
+	switch {
+	case KeyMatches(k, m.keys.Help, m.keys.Cancel):
+		m.showingHelp = false
+
+	case KeyMatches(k, m.keys.Up):
+		m.helpOffset = max(0, m.helpOffset-1)
+
+	case KeyMatches(k, m.keys.Down):
+		m.helpOffset = min(m.helpOffset+1, m.helpOverflow())
+	}
+}
+
+// helpRows is the number of lines the overlay has room for inside its
+// border and padding
+func (m Model) helpRows() int {
+	return max(1, m.viewport.Height-helpOverlayStyle.GetVerticalFrameSize())
+}
+
+// helpOverflow is how many lines of the overlay do not fit on screen
+func (m Model) helpOverflow() int {
+	return max(0, lipgloss.Height(m.helpContent())-m.helpRows())
+}
+
+// helpContent lays out the groups of bindings in as many columns as fit
+// the terminal
+func (m Model) helpContent() string {
+	maxWidth := m.width - helpOverlayStyle.GetHorizontalFrameSize()
+
+	var rows, row []string
+	rowWidth := 0
+	for _, group := range m.keys.helpGroups() {
+		column := renderHelpGroup(group)
+		if column == "" {
+			continue
+		}
+
+		width := lipgloss.Width(column)
+		if len(row) > 0 && rowWidth+helpColumnGap+width > maxWidth {
+			rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+			row, rowWidth = nil, 0
+		}
+		if len(row) > 0 {
+			row = append(row, strings.Repeat(" ", helpColumnGap))
+			rowWidth += helpColumnGap
+		}
+		row = append(row, column)
+		rowWidth += width
+	}
+	if len(row) > 0 {
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, row...))
+	}
+	return strings.Join(rows, "\n\n")
+}
+
+// renderHelpGroup renders a group's title over its keys and what they do,
+// leaving out bindings that are turned off
+func renderHelpGroup(group helpGroup) string {
+	var keys, descs []string
+	for _, b := range group.bindings {
+		if !b.Enabled() {
+			continue
+		}
+		keys = append(keys, helpKeyStyle.Render(b.Help().Key))
+		descs = append(descs, helpDescStyle.Render(b.Help().Desc))
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+
+	table := lipgloss.JoinHorizontal(lipgloss.Top,
+		strings.Join(keys, "\n"),
+		"  ",
+		strings.Join(descs, "\n"))
+	return helpTitleStyle.Render(group.title) + "\n\n" + table
+}
+
+// helpOverlay renders the bindings in a box centred over page, the
+// rendered viewport, leaving the page visible around it
+func (m Model) helpOverlay(page string) string {
+	lines := strings.Split(m.helpContent(), "\n")
+	offset := min(m.helpOffset, m.helpOverflow())
+	end := min(len(lines), offset+m.helpRows())
+
+	box := helpOverlayStyle.Render(strings.Join(lines[offset:end], "\n"))
+	return overlay(page, box, m.width, m.viewport.Height)
+}
+
+// overlay draws box centred over the first height lines of background,
+// which is width columns wide. Styling is reset either side of the box so
+// neither bleeds into the other.
+func overlay(background, box string, width, height int) string {
+	bg := strings.Split(background, "\n")
+	for len(bg) < height {
+		bg = append(bg, "")
+	}
+	bg = bg[:height]
+
+	boxLines := strings.Split(box, "\n")
+	boxWidth := lipgloss.Width(box)
+	top := max(0, (height-len(boxLines))/2)
+	left := max(0, (width-boxWidth)/2)
+
+	for i, line := range boxLines {
+		row := top + i
+		if row >= height {
+			break
+		}
+		under := bg[row]
+		before := ansi.Truncate(under, left, "")
+		before += strings.Repeat(" ", left-ansi.StringWidth(before))
+		line += strings.Repeat(" ", boxWidth-ansi.StringWidth(line))
+		after := ansi.TruncateLeft(under, left+boxWidth, "")
+		bg[row] = before + sgrReset + line + sgrReset + after
+	}
+	return strings.Join(bg, "\n")
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestOverlay(t *testing.T) {
+	tests := []struct {
+		name       string
+		background string
+		box        string
+		width      int
+		height     int
+		want       string
+	}{
+		{
+			name:       "centred over the page",
+			background: "aaaaaaaaaa\nbbbbbbbbbb\ncccccccccc\ndddddddddd",
+			box:        "XX\nYY",
+			width:      10,
+			height:     4,
+			want:       "aaaaaaaaaa\nbbbb" + sgrReset + "XX" + sgrReset + "bbbb\ncccc" + sgrReset + "YY" + sgrReset + "cccc\ndddddddddd",
+		},
+		{
+			name:       "short lines are padded under the box",
+			background: "ab\n\nab",
+			box:        "XX",
+			width:      6,
+			height:     3,
+			want:       "ab\n  " + sgrReset + "XX" + sgrReset + "\nab",
+		},
+		{
+			name:       "missing lines are added",
+			background: "ab",
+			box:        "XX",
+			width:      2,
+			height:     3,
+			want:       "ab\n" + sgrReset + "XX" + sgrReset + "\n",
+		},
+		{
+			name:       "page styling outside the box is kept",
+			background: "\x1b[1mbold text\x1b[0m",
+			box:        "X",
+			width:      9,
+			height:     1,
+			want:       "\x1b[1mbold\x1b[0m" + sgrReset + "X" + sgrReset + "\x1b[1mtext\x1b[0m",
+		},
+		{
+			name:       "wide runes",
+			background: "日本語日本",
+			box:        "XX",
+			width:      10,
+			height:     1,
+			want:       "日本" + sgrReset + "XX" + sgrReset + "日本",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlay(tt.background, tt.box, tt.width, tt.height); got != tt.want {
+				t.Errorf("overlay = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHelpOverlayKeepsPageVisible(t *testing.T) {
+	m := NewModel(120, 40, log.New(io.Discard))
+	page := strings.Repeat(strings.Repeat("p", m.width)+"\n", m.viewport.Height-1) + strings.Repeat("p", m.width)
+	m.openHelp()
+
+	lines := strings.Split(m.helpOverlay(page), "\n")
+	if len(lines) != m.viewport.Height {
+		t.Fatalf("%d lines, want %d", len(lines), m.viewport.Height)
+	}
+	if lines[0] != strings.Repeat("p", m.width) {
+		t.Errorf("first line = %q, want the page", lines[0])
+	}
+	middle := ansi.Strip(lines[len(lines)/2])
+	if !strings.HasPrefix(middle, "p") || !strings.HasSuffix(middle, "p") {
+		t.Errorf("middle line = %q, want the page either side of the box", middle)
+	}
+	for i, line := range lines {
+		if w := ansi.StringWidth(line); w != m.width {
+			t.Errorf("line %d is %d columns, want %d", i, w, m.width)
+		}
+	}
+}
@@ -0,0 +1,470 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// KeyMap holds every key binding in the portfolio, grouped by where they
+// apply. One key can mean different things in different places, such as /
+// which searches from a page and finds text in an open post.
+//
+// A key may also be a sequence of key presses separated by spaces, such as
+// "g g" for pressing g twice.
+type KeyMap struct {
+	// Everywhere
+	Quit      key.Binding
+	ForceQuit key.Binding
+	Search    key.Binding
+	Help      key.Binding
+	PrevPage  key.Binding
+	NextPage  key.Binding
+	Top       key.Binding
+	Bottom    key.Binding
+	JumpUp    key.Binding
+	JumpDown  key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+
+	// Lists, the contents of a post and project filters
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Back   key.Binding
+	Cancel key.Binding
+
+	// Blog list
+	Tags        key.Binding
+	Bookmark    key.Binding
+	ReadingList key.Binding
+	ClearFilter key.Binding
+
+	// Post reader
+	Find      key.Binding
+	NextMatch key.Binding
+	PrevMatch key.Binding
+	Contents  key.Binding
+
+	// Projects list
+	Filter key.Binding
+	Sort   key.Binding
+	Toggle key.Binding
+
+	// Search results
+	PrevResult key.Binding
+	NextResult key.Binding
+}
+
+// DefaultKeyMap returns the bindings the portfolio ships with
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Quit:      newBinding("quit", "q"),
+		ForceQuit: newBinding("quit", "ctrl+c"),
+		Search:    newBinding("search", "/"),
+		Help:      newBinding("help", "?"),
+		PrevPage:  newBinding("previous page", "left", "h"),
+		NextPage:  newBinding("next page", "right", "l"),
+		Top:       newBinding("go to top", "g g", "home"),
+		Bottom:    newBinding("go to bottom", "G", "end"),
+		JumpUp:    newBinding("up 10 lines", "ctrl+u"),
+		JumpDown:  newBinding("down 10 lines", "ctrl+d"),
+		PageUp:    newBinding("page up", "pgup"),
+		PageDown:  newBinding("page down", "pgdown", " "),
+
+		Up:     newBinding("up", "up", "k"),
+		Down:   newBinding("down", "down", "j"),
+		Select: newBinding("select", "enter"),
+		Back:   newBinding("back", "backspace"),
+		Cancel: newBinding("cancel", "esc"),
+
+		Tags:        newBinding("tags", "t"),
+		Bookmark:    newBinding("save", "b"),
+		ReadingList: newBinding("reading list", "r"),
+		ClearFilter: newBinding("clear filters", "x", "esc"),
+
+		Find:      newBinding("find", "/"),
+		NextMatch: newBinding("next match", "n"),
+		PrevMatch: newBinding("previous match", "N"),
+		Contents:  newBinding("contents", "t"),
+
+		Filter: newBinding("filter", "f"),
+		Sort:   newBinding("sort", "s"),
+		Toggle: newBinding("toggle", "enter", " "),
+
+		PrevResult: newBinding("previous result", "up", "ctrl+p"),
+		NextResult: newBinding("next result", "down", "ctrl+n"),
+	}
+}
+
+// ShortHelp returns the bindings shown when a page has no hints of its own
+func (km KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{
+		pairBinding(km.PrevPage, km.NextPage, "change page"),
+		pairBinding(km.Up, km.Down, "scroll"),
+		km.Search,
+		km.Help,
+		km.Quit,
+	}
+}
+
+// FullHelp returns every binding, grouped by where it applies
+func (km KeyMap) FullHelp() [][]key.Binding {
+	var groups [][]key.Binding
+	for _, group := range km.helpGroups() {
+		groups = append(groups, group.bindings)
+	}
+	return groups
+}
+
+// helpGroup is a titled group of bindings in the help overlay
+type helpGroup struct {
+	title    string
+	bindings []key.Binding
+}
+
+// helpGroups lists every binding under the place it applies, described
+// the way it behaves there
+func (km KeyMap) helpGroups() []helpGroup {
+	return []helpGroup{
+		{"🧭 Global", []key.Binding{
+			km.PrevPage, km.NextPage, km.Search, km.Help, km.Quit, km.ForceQuit,
+		}},
+		{"📜 Scrolling & lists", []key.Binding{
+			km.Up, km.Down, km.PageUp, km.PageDown, km.JumpUp, km.JumpDown, km.Top, km.Bottom,
+		}},
+		{"📚 Blog list", []key.Binding{
+			withDesc(km.Select, "read post"), km.Bookmark, km.ReadingList, km.Tags,
+			withDesc(km.ClearFilter, "clear tag filter"), withDesc(km.Back, "leave tags or list"),
+		}},
+		{"📖 Reader", []key.Binding{
+			km.Find, km.NextMatch, km.PrevMatch, withDesc(km.Cancel, "clear matches"),
+			km.Contents, km.Bookmark, withDesc(km.Back, "back to posts"),
+		}},
+		{"🛠️  Projects", []key.Binding{
+			withDesc(km.Select, "details"), km.Filter, withDesc(km.Toggle, "toggle filter"),
+			km.Sort, km.ClearFilter, withDesc(km.Back, "back to projects"),
+		}},
+		{"🔍 Search", []key.Binding{
+			km.PrevResult, km.NextResult, withDesc(km.Select, "open result"), withDesc(km.Cancel, "close search"),
+		}},
+	}
+}
+
+// fields names each binding for keymap files
+func (km *KeyMap) fields() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":        &km.Quit,
+		"forceQuit":   &km.ForceQuit,
+		"search":      &km.Search,
+		"help":        &km.Help,
+		"prevPage":    &km.PrevPage,
+		"nextPage":    &km.NextPage,
+		"top":         &km.Top,
+		"bottom":      &km.Bottom,
+		"jumpUp":      &km.JumpUp,
+		"jumpDown":    &km.JumpDown,
+		"pageUp":      &km.PageUp,
+		"pageDown":    &km.PageDown,
+		"up":          &km.Up,
+		"down":        &km.Down,
+		"select":      &km.Select,
+		"back":        &km.Back,
+		"cancel":      &km.Cancel,
+		"tags":        &km.Tags,
+		"bookmark":    &km.Bookmark,
+		"readingList": &km.ReadingList,
+		"clearFilter": &km.ClearFilter,
+		"find":        &km.Find,
+		"nextMatch":   &km.NextMatch,
+		"prevMatch":   &km.PrevMatch,
+		"contents":    &km.Contents,
+		"filter":      &km.Filter,
+		"sort":        &km.Sort,
+		"toggle":      &km.Toggle,
+		"prevResult":  &km.PrevResult,
+		"nextResult":  &km.NextResult,
+	}
+}
+
+// contexts groups the bindings that are matched against the same key
+// presses, such as everything that works while reading a post
+func (km *KeyMap) contexts() [][]*key.Binding {
+	global := []*key.Binding{
+		&km.Quit, &km.ForceQuit, &km.Search, &km.Help, &km.PrevPage, &km.NextPage,
+		&km.Top, &km.Bottom, &km.JumpUp, &km.JumpDown, &km.PageUp, &km.PageDown,
+		&km.Up, &km.Down,
+	}
+	with := func(bindings ...*key.Binding) []*key.Binding {
+		return append(append([]*key.Binding(nil), global...), bindings...)
+	}
+	return [][]*key.Binding{
+		with(&km.Select, &km.Bookmark, &km.ReadingList, &km.Tags, &km.ClearFilter, &km.Back),
+		with(&km.Find, &km.NextMatch, &km.PrevMatch, &km.Cancel, &km.Contents, &km.Bookmark, &km.Back),
+		with(&km.Select, &km.Filter, &km.Sort, &km.ClearFilter, &km.Back),
+		with(&km.Toggle, &km.ClearFilter, &km.Filter, &km.Back, &km.Cancel),
+		{&km.ForceQuit, &km.PrevResult, &km.NextResult, &km.Select, &km.Cancel},
+	}
+}
+
+// collision returns the name of a binding that shares a context with
+// name and is triggered by k, or by a sequence that k starts or ends up
+// in
+func (km *KeyMap) collision(name, k string) (string, bool) {
+	fields := km.fields()
+	names := make(map[*key.Binding]string, len(fields))
+	for n, b := range fields {
+		names[b] = n
+	}
+
+	own := fields[name]
+	for _, context := range km.contexts() {
+		shared := false
+		for _, b := range context {
+			shared = shared || b == own
+		}
+		if !shared {
+			continue
+		}
+		for _, b := range context {
+			if b == own || !b.Enabled() {
+				continue
+			}
+			for _, other := range b.Keys() {
+				if other == k || strings.HasPrefix(other, k+" ") || strings.HasPrefix(k, other+" ") {
+					return names[b], true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// viewportKeyMap scrolls the viewport with the portfolio's bindings. Left
+// and right switch pages, and the jump bindings are handled by the Model.
+func (km KeyMap) viewportKeyMap() viewport.KeyMap {
+	off := key.NewBinding(key.WithDisabled())
+	return viewport.KeyMap{
+		PageDown:     km.PageDown,
+		PageUp:       km.PageUp,
+		HalfPageUp:   off,
+		HalfPageDown: off,
+		Down:         km.Down,
+		Up:           km.Up,
+		Left:         off,
+		Right:        off,
+	}
+}
+
+// LoadKeyMap reads a JSON file mapping binding names, such as "bookmark"
+// or "nextPage", to the keys that trigger them. Bindings the file leaves
+// out keep their defaults, and an empty list turns a binding off. A key
+// the file adds to a binding must not already trigger another binding
+// that works in the same place.
+func LoadKeyMap(path string) (KeyMap, error) {
+	km := DefaultKeyMap()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return km, err
+	}
+	var overrides map[string][]string
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return km, fmt.Errorf("%s: %w", path, err)
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	defaults := km.fields()
+	merged := DefaultKeyMap()
+	fields := merged.fields()
+	for _, name := range names {
+		b, ok := fields[name]
+		if !ok {
+			return km, fmt.Errorf("%s: unknown key binding %q", path, name)
+		}
+		keys := overrides[name]
+		b.SetKeys(keys...)
+		b.SetHelp(helpKeys(keys), b.Help().Desc)
+		b.SetEnabled(len(keys) > 0)
+	}
+
+	// Only keys the file adds are checked, since some defaults share keys
+	// on purpose, such as / for search and for finding in a post
+	for _, name := range names {
+		for _, k := range overrides[name] {
+			if KeyMatches(k, *defaults[name]) {
+				continue
+			}
+			if other, ok := merged.collision(name, k); ok {
+				return km, fmt.Errorf("%s: key %q for %q is already bound to %q", path, k, name, other)
+			}
+		}
+	}
+	return merged, nil
+}
+
+// keyMap is the keymap new sessions start with
+var keyMap = DefaultKeyMap()
+
+// SetKeyMap makes sessions started afterwards use km. Call it before the
+// server starts.
+func SetKeyMap(km KeyMap) {
+	keyMap = km
+}
+
+// Keys returns the session's key bindings, for pages to match keys against
+func (m Model) Keys() KeyMap {
+	return m.keys
+}
+
+// keyPress is a key, or a sequence of keys such as "g g", that bindings
+// are matched against
+type keyPress string
+
+func (k keyPress) String() string {
+	return string(k)
+}
+
+// KeyMatches reports whether the key given to Page.Update triggers any of
+// the bindings
+func KeyMatches(k string, bindings ...key.Binding) bool {
+	return key.Matches(keyPress(k), bindings...)
+}
+
+// sequenceTimeout is how long a sequence such as "g g" waits for its next
+// key press before the presses so far are dropped
+const sequenceTimeout = time.Second
+
+// sequenceKey adds a key press to the sequence being typed. It returns the
+// key or finished sequence to act on, or false while the presses so far
+// could still become a sequence. A key that breaks off a sequence, or
+// arrives after sequenceTimeout, is used on its own.
+func (m *Model) sequenceKey(k string) (string, bool) {
+	if m.pendingKeys != "" && time.Since(m.pendingAt) > sequenceTimeout {
+		m.pendingKeys = ""
+	}
+
+	seq := k
+	if m.pendingKeys != "" {
+		seq = m.pendingKeys + " " + k
+	}
+	m.pendingKeys = ""
+
+	complete, prefix := false, false
+	for _, b := range m.keys.fields() {
+		if !b.Enabled() {
+			continue
+		}
+		for _, bk := range b.Keys() {
+			complete = complete || bk == seq
+			prefix = prefix || strings.HasPrefix(bk, seq+" ")
+		}
+	}
+
+	switch {
+	case complete && seq != k:
+		return seq, true
+	case prefix:
+		m.pendingKeys = seq
+		m.pendingAt = time.Now()
+		return "", false
+	case seq != k:
+		return m.sequenceKey(k)
+	}
+	return k, true
+}
+
+// newBinding creates a binding whose help lists its keys
+func newBinding(desc string, keys ...string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(helpKeys(keys), desc))
+}
+
+// withDesc returns b described as desc, for hints that say what a key
+// does in one place
+func withDesc(b key.Binding, desc string) key.Binding {
+	b.SetHelp(b.Help().Key, desc)
+	return b
+}
+
+// without returns b less the keys that other bindings take first, for
+// hints in places that check them before b
+func without(b key.Binding, others ...key.Binding) key.Binding {
+	var keys []string
+	for _, k := range b.Keys() {
+		if !KeyMatches(k, others...) {
+			keys = append(keys, k)
+		}
+	}
+	b.SetKeys(keys...)
+	b.SetHelp(helpKeys(keys), b.Help().Desc)
+	b.SetEnabled(b.Enabled() && len(keys) > 0)
+	return b
+}
+
+// pairBinding joins two opposite bindings, such as up and down, into one
+// hint
+func pairBinding(a, b key.Binding, desc string) key.Binding {
+	pair := key.NewBinding(
+		key.WithKeys(append(a.Keys(), b.Keys()...)...),
+		key.WithHelp(firstKey(a)+"/"+firstKey(b), desc),
+	)
+	pair.SetEnabled(a.Enabled() || b.Enabled())
+	return pair
+}
+
+func firstKey(b key.Binding) string {
+	if len(b.Keys()) == 0 {
+		return ""
+	}
+	return prettyKey(b.Keys()[0])
+}
+
+// keyHint names b's keys for hints written into a page, such as "Enter"
+func keyHint(b key.Binding) string {
+	return b.Help().Key
+}
+
+// helpKeys lists keys the way hints show them, such as "↑/k"
+func helpKeys(keys []string) string {
+	pretty := make([]string, len(keys))
+	for i, k := range keys {
+		pretty[i] = prettyKey(k)
+	}
+	return strings.Join(pretty, "/")
+}
+
+func prettyKey(k string) string {
+	switch k {
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case " ":
+		return "Space"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case "enter", "esc", "backspace", "home", "end", "tab":
+		return strings.ToUpper(k[:1]) + k[1:]
+	}
+	if rest, ok := strings.CutPrefix(k, "ctrl+"); ok {
+		return "Ctrl+" + strings.ToUpper(rest)
+	}
+	return strings.ReplaceAll(k, " ", "")
+}
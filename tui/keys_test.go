@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSequenceKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		presses []string
+		// stale marks presses that arrive after sequenceTimeout
+		stale map[int]bool
+		want  []string
+	}{
+		{name: "single key", presses: []string{"j"}, want: []string{"j"}},
+		{name: "sequence", presses: []string{"g", "g"}, want: []string{"", "g g"}},
+		{name: "broken sequence uses the key alone", presses: []string{"g", "j"}, want: []string{"", "j"}},
+		{name: "broken sequence can start a new one", presses: []string{"g", "x", "g", "g"}, want: []string{"", "x", "", "g g"}},
+		{name: "timed-out prefix is dropped", presses: []string{"g", "g"}, stale: map[int]bool{1: true}, want: []string{"", ""}},
+		{name: "timed-out prefix then a full sequence", presses: []string{"g", "g", "g"}, stale: map[int]bool{1: true}, want: []string{"", "", "g g"}},
+		{name: "other key after a timeout", presses: []string{"g", "G"}, stale: map[int]bool{1: true}, want: []string{"", "G"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := Model{keys: DefaultKeyMap()}
+			for i, k := range tt.presses {
+				if tt.stale[i] {
+					m.pendingAt = m.pendingAt.Add(-2 * sequenceTimeout)
+				}
+				got, ok := m.sequenceKey(k)
+				if ok != (tt.want[i] != "") || got != tt.want[i] {
+					t.Errorf("press %d (%q) = %q, %v, want %q", i, k, got, ok, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSequenceKeyWaitsBeforeTimeout(t *testing.T) {
+	m := Model{keys: DefaultKeyMap()}
+	m.sequenceKey("g")
+	m.pendingAt = time.Now().Add(-sequenceTimeout / 2)
+	if got, ok := m.sequenceKey("g"); !ok || got != "g g" {
+		t.Errorf("second press = %q, %v, want %q, true", got, ok, "g g")
+	}
+}
+
+func TestLoadKeyMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+		check   func(t *testing.T, km KeyMap)
+	}{
+		{
+			name: "override keeps other defaults",
+			file: `{"bookmark": ["m"]}`,
+			check: func(t *testing.T, km KeyMap) {
+				if !KeyMatches("m", km.Bookmark) || KeyMatches("b", km.Bookmark) {
+					t.Errorf("bookmark keys = %v, want [m]", km.Bookmark.Keys())
+				}
+				if km.Bookmark.Help().Key != "m" {
+					t.Errorf("bookmark help = %q, want %q", km.Bookmark.Help().Key, "m")
+				}
+				if !KeyMatches("t", km.Tags) {
+					t.Errorf("tags keys = %v, want the default", km.Tags.Keys())
+				}
+			},
+		},
+		{
+			name: "empty list disables a binding",
+			file: `{"readingList": []}`,
+			check: func(t *testing.T, km KeyMap) {
+				if km.ReadingList.Enabled() {
+					t.Error("reading list is still enabled")
+				}
+			},
+		},
+		{
+			name: "sequences and special keys",
+			file: `{"top": ["g t", "home"], "pageDown": ["pgdown"]}`,
+			check: func(t *testing.T, km KeyMap) {
+				if !KeyMatches("g t", km.Top) || KeyMatches("g g", km.Top) {
+					t.Errorf("top keys = %v", km.Top.Keys())
+				}
+				if KeyMatches(" ", km.PageDown) {
+					t.Errorf("page down keys = %v", km.PageDown.Keys())
+				}
+			},
+		},
+		{
+			name: "swapped keys do not collide",
+			file: `{"bookmark": ["r"], "readingList": ["b"]}`,
+			check: func(t *testing.T, km KeyMap) {
+				if !KeyMatches("r", km.Bookmark) || !KeyMatches("b", km.ReadingList) {
+					t.Errorf("bookmark = %v, reading list = %v", km.Bookmark.Keys(), km.ReadingList.Keys())
+				}
+			},
+		},
+		{
+			name: "keys shared in separate places",
+			file: `{"bookmark": ["s"]}`,
+			check: func(t *testing.T, km KeyMap) {
+				if !KeyMatches("s", km.Bookmark) || !KeyMatches("s", km.Sort) {
+					t.Errorf("bookmark = %v, sort = %v", km.Bookmark.Keys(), km.Sort.Keys())
+				}
+			},
+		},
+		{
+			name:    "unknown action name",
+			file:    `{"bookmark": ["m"], "teleport": ["z"]}`,
+			wantErr: `unknown key binding "teleport"`,
+		},
+		{
+			name:    "collides with an existing binding",
+			file:    `{"bookmark": ["t"]}`,
+			wantErr: `key "t" for "bookmark" is already bound to "tags"`,
+		},
+		{
+			name:    "collides with a global binding",
+			file:    `{"contents": ["q"]}`,
+			wantErr: `key "q" for "contents" is already bound to "quit"`,
+		},
+		{
+			name:    "collides with the start of a sequence",
+			file:    `{"find": ["g"]}`,
+			wantErr: `key "g" for "find" is already bound to "top"`,
+		},
+		{
+			name:    "invalid JSON",
+			file:    `{"bookmark": "m"}`,
+			wantErr: "cannot unmarshal",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "keymap.json")
+			if err := os.WriteFile(path, []byte(tt.file), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			km, err := LoadKeyMap(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadKeyMap error = %v, want one containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadKeyMap error = %v", err)
+			}
+			tt.check(t, km)
+		})
+	}
+}
+
+func TestLoadKeyMapMissingFile(t *testing.T) {
+	if _, err := LoadKeyMap(filepath.Join(t.TempDir(), "missing.json")); !os.IsNotExist(err) {
+		t.Errorf("LoadKeyMap error = %v, want a not-exist error", err)
+	}
+}
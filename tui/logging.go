@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+)
+
+type contextKey string
+
+const (
+	sessionIDKey     contextKey = "session-id"
+	sessionLoggerKey contextKey = "session-logger"
+)
+
+// NewSessionID returns a short random identifier used to correlate
+// every log line belonging to a single visitor session
+func NewSessionID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return time.Now().Format("20060102150405.000000")
+	}
+	return hex.EncodeToString(b)
+}
+
+// SessionID returns the correlation ID assigned to an SSH session by
+// LoggingMiddleware, or an empty string if none was assigned
+func SessionID(s ssh.Session) string {
+	id, _ := s.Context().Value(sessionIDKey).(string)
+	return id
+}
+
+// SessionLogger returns the logger carrying the session's correlation ID,
+// falling back to the default logger outside of LoggingMiddleware
+func SessionLogger(s ssh.Session) *log.Logger {
+	if logger, ok := s.Context().Value(sessionLoggerKey).(*log.Logger); ok {
+		return logger
+	}
+	return log.Default()
+}
+
+// LoggingMiddleware assigns a session ID to every connection and logs
+// connect and disconnect events as structured fields. It must be the
+// outermost middleware so the ID is available to the rest of the chain.
+func LoggingMiddleware() wish.Middleware {
+	return func(next ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			id := NewSessionID()
+			logger := log.Default().With("session", id)
+			s.Context().SetValue(sessionIDKey, id)
+			s.Context().SetValue(sessionLoggerKey, logger)
+
+			start := time.Now()
+			pty, _, _ := s.Pty()
+			logger.Info("connect",
+				"user", s.User(),
+				"remote", s.RemoteAddr().String(),
+				"publicKey", s.PublicKey() != nil,
+				"command", s.Command(),
+				"term", pty.Term,
+				"width", pty.Window.Width,
+				"height", pty.Window.Height,
+				"client", s.Context().ClientVersion(),
+			)
+
+			next(s)
+
+			logger.Info("disconnect",
+				"remote", s.RemoteAddr().String(),
+				"duration", time.Since(start).String(),
+			)
+		}
+	}
+}
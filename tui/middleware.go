@@ -1,16 +1,24 @@
 package tui
 
 import (
+	"io"
+
+	"github.com/Arpan-206/terminal-portfolio/recorder"
+	"github.com/Arpan-206/terminal-portfolio/visitor"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/ssh"
 	"github.com/charmbracelet/wish"
 	"github.com/charmbracelet/wish/bubbletea"
 	"github.com/muesli/termenv"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 // CustomBubbleteaMiddleware creates a custom Bubble Tea middleware
-// that wraps tea.Program with SSH session integration
-func CustomBubbleteaMiddleware() wish.Middleware {
+// that wraps tea.Program with SSH session integration. When rec is
+// enabled, each session's output and resizes are recorded. Visitors who
+// authenticate with a public key have their reading state kept in
+// visitors.
+func CustomBubbleteaMiddleware(rec *recorder.Recorder, visitors *visitor.Store) wish.Middleware {
 	teaHandler := func(s ssh.Session) *tea.Program {
 		pty, _, active := s.Pty()
 		if !active {
@@ -18,13 +26,70 @@ func CustomBubbleteaMiddleware() wish.Middleware {
 			return nil
 		}
 
+		logger := SessionLogger(s)
 		m := NewModel(
 			pty.Window.Width,
 			pty.Window.Height,
+			logger,
 		)
 
-		return tea.NewProgram(m, append(bubbletea.MakeOptions(s), tea.WithAltScreen())...)
+		if id := VisitorID(s); id != "" {
+			m.SetVisitor(visitors, id)
+			go func() {
+				<-s.Context().Done()
+				if err := visitors.Save(); err != nil {
+					logger.Error("Could not save visitor state", "error", err)
+				}
+			}()
+		}
+
+		// "ssh -t host <post-id>" opens straight into a post
+		if cmd := s.Command(); len(cmd) > 0 && !m.OpenPost(cmd[0]) {
+			logger.Warn("unknown post requested", "id", cmd[0])
+		}
+
+		opts := append(bubbletea.MakeOptions(s), tea.WithAltScreen())
+
+		recording, err := rec.Start(SessionID(s), pty.Window.Width, pty.Window.Height, pty.Term)
+		if err != nil {
+			logger.Error("Could not start recording", "error", err)
+		}
+		if recording != nil {
+			logger.Info("recording session", "dir", rec.Dir())
+			go func() {
+				<-s.Context().Done()
+				recording.Close()
+			}()
+			opts = append(opts,
+				tea.WithOutput(io.MultiWriter(s, recording)),
+				tea.WithFilter(recordResize(recording)),
+			)
+		}
+
+		return tea.NewProgram(m, opts...)
 	}
 
 	return bubbletea.MiddlewareWithProgramHandler(teaHandler, termenv.ANSI256)
 }
+
+// VisitorID identifies a visitor by the SHA256 fingerprint of the public
+// key they authenticated with, or returns an empty string for visitors
+// without one
+func VisitorID(s ssh.Session) string {
+	key := s.PublicKey()
+	if key == nil {
+		return ""
+	}
+	return gossh.FingerprintSHA256(key)
+}
+
+// recordResize returns a program filter that mirrors window size changes
+// into a recording
+func recordResize(recording *recorder.Recording) func(tea.Model, tea.Msg) tea.Msg {
+	return func(_ tea.Model, msg tea.Msg) tea.Msg {
+		if size, ok := msg.(tea.WindowSizeMsg); ok {
+			recording.Resize(size.Width, size.Height)
+		}
+		return msg
+	}
+}
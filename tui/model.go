@@ -3,43 +3,75 @@ package tui
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-)
-
-// PageType represents different page types
-type PageType int
+	"github.com/charmbracelet/log"
+	"github.com/charmbracelet/x/ansi"
 
-const (
-	HomePage PageType = iota
-	ProjectsPage
-	BlogPage
-	AboutPage
-	ContactPage
+	"github.com/Arpan-206/terminal-portfolio/visitor"
 )
 
-// BlogEntry represents a blog post
-type BlogEntry struct {
-	Title   string
-	Summary string
-	Content string
-	Date    string
-}
-
 // Model represents the terminal UI state
 type Model struct {
 	width             int
 	height            int
-	currentPage       PageType
-	pages             []string
-	blogEntries       []BlogEntry
+	currentPage       int
+	pages             []ContentPage
+	views             []Page
+	blogEntries       []BlogPost
 	selectedBlogEntry int
 	viewingBlogEntry  bool
 	viewport          viewport.Model
 	ready             bool
-	lastKey 		  string
+	pendingKeys       string
+	pendingAt         time.Time
+	keys              KeyMap
+	help              help.Model
+	showingHelp       bool
+	helpOffset        int
+	logger            *log.Logger
+	projects          []Project
+	searchIndex       *SearchIndex
+	searching         bool
+	searchInput       textinput.Model
+	searchResults     []SearchResult
+	selectedResult    int
+	finding           bool
+	findInput         textinput.Model
+	findMatches       []findMatch
+	findCurrent       int
+	findFrom          int
+	postOpened        time.Time
+	renderedPost      *renderCache
+	renderedProject   *renderCache
+	viewingTags       bool
+	selectedTag       int
+	tagFilter         string
+	tocOpen           bool
+	tocFocused        bool
+	tocSelected       int
+	tocHeadings       []Heading
+	tocLines          []int
+	progress          progress.Model
+	visitors          *visitor.Store
+	visitorID         string
+	viewingSaved      bool
+	selectedSaved     int
+	notice            string
+	selectedProject   int
+	viewingProject    bool
+	viewingFilters    bool
+	selectedChip      int
+	techFilters       []string
+	statusFilters     []string
+	projectSort       projectSort
 }
 
 // Styles
@@ -94,47 +126,124 @@ var (
 		Width(70)
 )
 
-// NewModel creates a new Model instance
-func NewModel(width, height int) Model {
-	blogEntries := GetBlogPosts()
+// NewModel creates a new Model instance. The logger should carry the
+// session's correlation fields; nil falls back to the default logger.
+func NewModel(width, height int, logger *log.Logger) Model {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	posts := LoadBlogPosts()
+	projects := LoadProjects()
+	pages, views := sessionPages()
+
+	input := textinput.New()
+	input.Prompt = searchPromptStyle.Render("/ ")
+	input.Placeholder = "search posts and projects"
+	input.CharLimit = 100
+
+	find := textinput.New()
+	find.Prompt = "/"
+	find.CharLimit = 100
 
 	vp := viewport.New(width, height-4) // Reserve space for navbar and footer
 	vp.Style = lipgloss.NewStyle().
 		BorderStyle(lipgloss.HiddenBorder()).
 		PaddingLeft(0).
 		PaddingRight(0)
+	vp.KeyMap = keyMap.viewportKeyMap()
 
 	return Model{
 		width:             width,
 		height:            height,
-		currentPage:       HomePage,
-		pages:             []string{"Home", "Projects", "Blog", "About", "Contact"},
-		blogEntries:       blogEntries,
+		currentPage:       max(0, nextVisiblePage(pages, -1, 1)),
+		pages:             pages,
+		views:             views,
+		blogEntries:       posts,
 		selectedBlogEntry: 0,
 		viewingBlogEntry:  false,
 		viewport:          vp,
 		ready:             false,
-		lastKey: 			"",
+		keys:              keyMap,
+		help:              newHelp(),
+		logger:            logger,
+		projects:          projects,
+		searchIndex:       NewSearchIndex(posts, projects),
+		searchInput:       input,
+		findInput:         find,
+		renderedPost:      &renderCache{},
+		renderedProject:   &renderCache{},
+		visitors:          visitor.NewMemory(),
+		visitorID:         anonymousVisitor,
+		progress: progress.New(
+			progress.WithSolidFill("#7D56F4"),
+			progress.WithWidth(16),
+			progress.WithoutPercentage(),
+		),
 	}
 }
 
-// Init initializes the model
+// Init initializes the model, starting any pages with models of their own
 func (m Model) Init() tea.Cmd {
-	return nil
+	var cmds []tea.Cmd
+	for _, page := range m.views {
+		if pm, ok := page.(PageModel); ok {
+			cmds = append(cmds, pm.Init())
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// SetVisitor identifies the visitor so reading positions and bookmarks
+// are kept in store. Until it is called, or when id is empty, they last
+// only for the session.
+func (m *Model) SetVisitor(store *visitor.Store, id string) {
+	if store == nil || id == "" {
+		return
+	}
+	m.visitors = store
+	m.visitorID = id
 }
 
 // Update handles model updates
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// Pages with models of their own see everything but keys, which reach
+	// them through Page.Update
+	var cmds []tea.Cmd
+	if _, ok := msg.(tea.KeyMsg); !ok {
+		for _, page := range m.views {
+			if pm, ok := page.(PageModel); ok {
+				cmds = append(cmds, pm.HandleMsg(msg))
+			}
+		}
+	}
+
+	// Check the open post before and after the message, so a short post
+	// counts as read when the visitor leaves it
+	m.rememberPosition()
+	model, cmd := m.update(msg)
+	if next, ok := model.(Model); ok {
+		next.rememberPosition()
+	}
+	return model, tea.Batch(append(cmds, cmd)...)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		// Posts reflow at the new width, so keep the reader at the same
+		// point in the post rather than on the same line
+		reading := m.viewport.ScrollPercent()
+
 		if !m.ready {
 			m.viewport = viewport.New(msg.Width, msg.Height-4)
 			m.viewport.Style = lipgloss.NewStyle().
 				BorderStyle(lipgloss.HiddenBorder()).
 				PaddingLeft(0).
 				PaddingRight(0)
+			m.viewport.KeyMap = m.keys.viewportKeyMap()
 			m.ready = true
 		} else {
 			m.viewport.Width = msg.Width
@@ -147,137 +256,169 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		
 		m.width = msg.Width
 		m.height = msg.Height
+		m.viewport.Width = m.readerWidth()
 		
+		if m.viewingBlogEntry && m.findInput.Value() != "" {
+			// The post reflows at the new width, so find the matches again
+			m.findFrom = 0
+			m.runFind()
+			break
+		}
+
 		// Update viewport content
 		m.updateViewportContent()
-
-	case tea.KeyMsg:
-		key := msg.String()
-
-		// Detect capital letters (Shift+key)
-		var shifted rune
-		if len(msg.Runes) == 1 {
-			
-			shifted = msg.Runes[0]
-			fmt.Println(shifted)
+		if m.viewingBlogEntry {
+			m.scrollToFraction(reading)
 		}
 
-		if key == "ctrl+c" || key == "q" {
+	case tea.KeyMsg:
+		if key.Matches(msg, m.keys.ForceQuit) {
 			return m, tea.Quit
 		}
-
-		switch key {
-			case "left", "h":
-				if !m.viewingBlogEntry {
-					if m.currentPage > 0 {
-						m.currentPage--
-						m.updateViewportContent()
-					}
-				}
-
-			case "right", "l":
-				if !m.viewingBlogEntry {
-					if int(m.currentPage) < len(m.pages)-1 {
-						m.currentPage++
-						m.updateViewportContent()
-					}
-				}
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		if m.finding {
+			return m.updateFind(msg)
 		}
 
+		// Notices only last until the next key
+		m.notice = ""
 
-		if shifted == 'G' {
-			// Shift+g = G = jump bottom
-			if m.currentPage == BlogPage && !m.viewingBlogEntry {
-				m.selectedBlogEntry = len(m.blogEntries) - 1
-			}
-
-        m.viewport.GotoBottom()
-
-		content := m.getPageContent()
-		m.viewport.SetContent(content)
-        return m, nil
+		// Wait for the rest of sequences such as "g g"
+		k, ok := m.sequenceKey(msg.String())
+		if !ok {
+			return m, nil
 		}
 
-		if key == "g" {
-			if m.lastKey == "g" {
-				// GG detected
-				m.viewport.GotoTop()
-				if m.currentPage == BlogPage && !m.viewingBlogEntry {
-					m.selectedBlogEntry = 0
-				}
-				m.updateViewportContent()
-				m.lastKey = ""
-				return m, nil
-			}
+		if KeyMatches(k, m.keys.Quit) {
+			return m, tea.Quit
+		}
 
-			// Single g: wait for next key
-			m.lastKey = "g"
+		// The help overlay keeps every other key from the page under it
+		if m.showingHelp {
+			m.updateHelp(k)
+			return m, nil
+		}
+		if KeyMatches(k, m.keys.Help) {
+			m.openHelp()
 			return m, nil
 		}
 
-		// Any other key cancels "g" state
-		m.lastKey = ""
-	
-		switch key {
-			case "ctrl+u":
-				m.viewport.LineUp(10)
-				return m, nil
+		if used, cmd := m.views[m.currentPage].Update(&m, k); used {
+			return m, cmd
+		}
 
-			case "ctrl+d":
-				m.viewport.LineDown(10)
-				return m, nil
+		// Keys every page shares
+		switch {
+			case KeyMatches(k, m.keys.Search):
+				return m, m.openSearch()
 
-			case "up", "k":
-				if m.currentPage == BlogPage && !m.viewingBlogEntry {
-					if m.selectedBlogEntry > 0 {
-						m.selectedBlogEntry--
-						m.updateViewportContent()
-					}
-				} else if m.viewingBlogEntry || (m.currentPage != BlogPage) {
-					m.viewport, cmd = m.viewport.Update(msg)
-					return m, cmd
+			case KeyMatches(k, m.keys.PrevPage):
+				if prev := nextVisiblePage(m.pages, m.currentPage, -1); prev >= 0 {
+					m.currentPage = prev
+					m.logPageView()
+					m.updateViewportContent()
 				}
 				return m, nil
 
-			case "down", "j":
-				if m.currentPage == BlogPage && !m.viewingBlogEntry {
-					if m.selectedBlogEntry < len(m.blogEntries)-1 {
-						m.selectedBlogEntry++
-						m.updateViewportContent()
-					}
-				} else if m.viewingBlogEntry || (m.currentPage != BlogPage) {
-					m.viewport, cmd = m.viewport.Update(msg)
-					return m, cmd
+			case KeyMatches(k, m.keys.NextPage):
+				if next := nextVisiblePage(m.pages, m.currentPage, 1); next >= 0 {
+					m.currentPage = next
+					m.logPageView()
+					m.updateViewportContent()
 				}
 				return m, nil
 
+			case KeyMatches(k, m.keys.Bottom):
+				m.viewport.GotoBottom()
+				return m, nil
 
-			case "enter":
-				if m.currentPage == BlogPage && !m.viewingBlogEntry {
-					m.viewingBlogEntry = true
-					m.updateViewportContent()
-				}
+			case KeyMatches(k, m.keys.Top):
+				m.viewport.GotoTop()
 				return m, nil
 
-			case "backspace":
-				if m.viewingBlogEntry {
-					m.viewingBlogEntry = false
-					m.updateViewportContent()
-				}			
+			case KeyMatches(k, m.keys.JumpUp):
+				m.viewport.LineUp(10)
 				return m, nil
 
+			case KeyMatches(k, m.keys.JumpDown):
+				m.viewport.LineDown(10)
+				return m, nil
 		}
 	}
 
+	if m.searching {
+		// Keep the cursor blinking
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		m.viewport.SetContent(m.getSearchContent())
+		return m, cmd
+	}
+	if m.finding {
+		m.findInput, cmd = m.findInput.Update(msg)
+		return m, cmd
+	}
+
 	// Update viewport
 	m.viewport, cmd = m.viewport.Update(msg)
 	return m, cmd
 }
 
+// logPageView records a navigation to the current page
+func (m Model) logPageView() {
+	m.logger.Info("page view", "page", m.pages[m.currentPage].ID)
+}
+
+// showPage switches to the page with the given ID, such as the Blog
+// section
+func (m *Model) showPage(id string) {
+	if i := findPage(m.pages, id); i >= 0 {
+		m.currentPage = i
+	}
+}
+
+// nextVisiblePage returns the index of the first page after from, in the
+// direction step, that is shown in the navbar, or -1 if there is none
+func nextVisiblePage(pages []ContentPage, from, step int) int {
+	for i := from + step; i >= 0 && i < len(pages); i += step {
+		if !pages[i].Hidden {
+			return i
+		}
+	}
+	return -1
+}
+
+// OpenPost shows the post with the given ID, as if it had been chosen from
+// the blog list. It reports whether a published post with that ID exists.
+func (m *Model) OpenPost(id string) bool {
+	for i, entry := range m.blogEntries {
+		if entry.ID == id {
+			m.showPage(BlogPageID)
+			m.selectedBlogEntry = i
+			m.viewingBlogEntry = true
+			m.logPostOpen()
+			m.updateViewportContent()
+			m.restorePosition()
+			return true
+		}
+	}
+	return false
+}
+
+// logPostOpen records the visitor opening the selected blog post
+func (m Model) logPostOpen() {
+	if m.selectedBlogEntry >= len(m.blogEntries) {
+		return
+	}
+	entry := m.blogEntries[m.selectedBlogEntry]
+	m.logger.Info("post open", "id", entry.ID, "title", entry.Title, "date", entry.DateString())
+}
+
 // updateViewportContent updates the viewport content based on current state
 func (m *Model) updateViewportContent() {
 	content := m.getPageContent()
 	m.viewport.SetContent(content)
+	m.indexHeadings(content)
 	m.viewport.GotoTop()
 }
 
@@ -293,11 +434,17 @@ func (m Model) View() string {
 	// Build footer - always visible at bottom
 	footer := m.renderFooter()
 
-	// Viewport handles the scrollable content
+	// Viewport handles the scrollable content, with the help overlay drawn
+	// over it when open
+	body := m.readerView()
+	if m.showingHelp {
+		body = m.helpOverlay(body)
+	}
+
 	view := lipgloss.JoinVertical(
 		lipgloss.Left,
 		navbar,
-		m.viewport.View(),
+		body,
 		footer,
 	)
 
@@ -305,139 +452,50 @@ func (m Model) View() string {
 }
 
 func (m Model) renderNavbar() string {
+	navbar := "📍 Arpan's Portfolio  |  " + m.renderNavItems(ContentPage.NavTitle)
+	if lipgloss.Width(navbar)+navbarStyle.GetHorizontalFrameSize() > m.width {
+		// Drop the icons rather than wrap onto a second line
+		navbar = "📍 Arpan's Portfolio  |  " + m.renderNavItems(func(p ContentPage) string { return p.Title })
+	}
+	return navbarStyle.Render(navbar)
+}
+
+// renderNavItems renders a navbar entry for each visible page, labelled
+// with label
+func (m Model) renderNavItems(label func(ContentPage) string) string {
 	var navItems []string
 	
 	for i, page := range m.pages {
-		if PageType(i) == m.currentPage {
-			navItems = append(navItems, activeNavStyle.Render(page))
-		} else {
-			navItems = append(navItems, inactiveNavStyle.Render(page))
+		if i == m.currentPage {
+			navItems = append(navItems, activeNavStyle.Render(label(page)))
+		} else if !page.Hidden {
+			navItems = append(navItems, inactiveNavStyle.Render(label(page)))
 		}
 	}
 
-	navbar := strings.Join(navItems, " ")
-	return navbarStyle.Render("📍 Arpan's Portfolio  |  " + navbar)
+	return strings.Join(navItems, " ")
 }
 
 func (m Model) getPageContent() string {
-	switch m.currentPage {
-	case HomePage:
-		return m.getHomeContent()
-	case ProjectsPage:
-		return m.getProjectsContent()
-	case BlogPage:
-		if m.viewingBlogEntry {
-			return m.getBlogEntryContent()
-		}
-		return m.getBlogContent()
-	case AboutPage:
-		return m.getAboutContent()
-	case ContactPage:
-		return m.getContactContent()
-	default:
-		return "Page not found"
+	if m.searching {
+		return m.getSearchContent()
 	}
-}
-
-func (m Model) getHomeContent() string {
-	markdownContent := `# 🚀 Welcome to Arpan's Terminal Portfolio!
-
-Hi there! I'm **Arpan Pandey**, a passionate tech enthusiast and developer.
-
-## 🔗 Connect with me
-
-- **GitHub:** [https://github.com/Arpan-206](https://github.com/Arpan-206)
-- **LinkedIn:** [https://www.linkedin.com/in/arpan-pandey/](https://www.linkedin.com/in/arpan-pandey/)
-
-✨ Navigate using arrow keys to explore my projects and blog posts!
-
-💡 This portfolio is built with **Go**, **Bubble Tea**, and lots of ❤️
-
-## 🎯 What you'll find here
-
-- My latest projects and open source contributions
-- Technical blog posts and tutorials
-- Information about my skills and experience  
-- Ways to get in touch and collaborate
 
-## 🌟 Features of this terminal portfolio
-
-- **Fully keyboard navigable** interface
-- **Responsive design** that adapts to your terminal size
-- **Beautiful styling** with Lipgloss and Glamour
-- **Smooth scrolling** for long content
-- **Interactive blog post viewer** with markdown rendering
-
-## ⚡ Quick navigation tips
-
-- Use **← →** arrows to switch between main sections
-- Use **↑ ↓** arrows to navigate within sections
-- Press **Enter** to open blog posts
-- Press **Backspace** to go back from blog posts
-- Press **q** or **Ctrl+C** to quit
-
----
-
-**Happy exploring!** 🎉`
-
-	// Render the markdown content using Glamour
-	renderedContent := renderMarkdownForDisplay(markdownContent, m.width)
-	
-	return contentStyle.Render(renderedContent)
-}
-
-func (m Model) getProjectsContent() string {
-	projects := GetFeaturedProjects()
-	
-	var markdownContent strings.Builder
-	markdownContent.WriteString("# 🛠️ Featured Projects\n\n")
-	markdownContent.WriteString("Here are some of my notable projects and contributions:\n\n")
-	
-	for i, project := range projects {
-		markdownContent.WriteString(fmt.Sprintf("## %d. %s\n\n", i+1, project.Name))
-		markdownContent.WriteString(fmt.Sprintf("**Description:** %s\n\n", project.Description))
-		
-		// Tech stack
-		markdownContent.WriteString("**Technologies:**\n")
-		for _, tech := range project.Tech {
-			markdownContent.WriteString(fmt.Sprintf("- %s\n", tech))
-		}
-		markdownContent.WriteString("\n")
-		
-		// Features
-		if len(project.Features) > 0 {
-			markdownContent.WriteString("**Key Features:**\n")
-			for _, feature := range project.Features {
-				markdownContent.WriteString(fmt.Sprintf("- %s\n", feature))
-			}
-			markdownContent.WriteString("\n")
-		}
-		
-		// Status and URL
-		markdownContent.WriteString(fmt.Sprintf("**Status:** %s\n", project.Status))
-		if project.URL != "" {
-			markdownContent.WriteString(fmt.Sprintf("**Repository:** [%s](%s)\n", project.URL, project.URL))
-		}
-		
-		markdownContent.WriteString("\n---\n\n")
-	}
-	
-	markdownContent.WriteString("## 🔗 Links\n\n")
-	markdownContent.WriteString("Check out my GitHub profile for complete project listings, source code, and detailed documentation:\n\n")
-	markdownContent.WriteString("**GitHub:** [https://github.com/Arpan-206](https://github.com/Arpan-206)\n\n")
-	markdownContent.WriteString("🚀 Always working on something new and exciting!\n")
-	
-	// Render the markdown content using Glamour
-	renderedContent := renderMarkdownForDisplay(markdownContent.String(), m.width)
-	
-	return contentStyle.Render(renderedContent)
+	return m.views[m.currentPage].View(&m)
 }
 
 func (m Model) getBlogContent() string {
 	var cards []string
 	
-	for i, entry := range m.blogEntries {
-		cardContent := fmt.Sprintf("📝 %s\n\n%s\n\n📅 %s", entry.Title, entry.Summary, entry.Date)
+	for _, i := range m.visibleBlogEntries() {
+		entry := m.blogEntries[i]
+		cardContent := fmt.Sprintf("📝 %s\n\n%s\n\n%s", entry.Title, entry.Summary, entry.Byline())
+		if len(entry.Tags) > 0 {
+			cardContent += "\n🏷️  " + formatTags(entry.Tags)
+		}
+		if badges := m.postBadges(i); badges != "" {
+			cardContent += "\n" + badges
+		}
 		
 		if i == m.selectedBlogEntry {
 			cards = append(cards, selectedCardStyle.Render(cardContent))
@@ -446,7 +504,12 @@ func (m Model) getBlogContent() string {
 		}
 	}
 	
-	header := contentStyle.Render("📚 Blog Posts\n\nUse ↑/↓ to navigate posts, Enter to read, scroll within posts\n")
+	title := "📚 Blog Posts"
+	if m.tagFilter != "" {
+		title += " tagged " + m.tagFilter
+	}
+	header := contentStyle.Render(title + fmt.Sprintf("\n\nUse %s to navigate posts, %s to read, %s to browse tags\n",
+		keyHint(pairBinding(m.keys.Up, m.keys.Down, "")), keyHint(m.keys.Select), keyHint(m.keys.Tags)))
 	content := header + "\n" + strings.Join(cards, "\n")
 	
 	return content
@@ -457,236 +520,103 @@ func (m Model) getBlogEntryContent() string {
 		return contentStyle.Render("Blog entry not found")
 	}
 	
+	width := m.readerWidth()
+	if content, ok := m.renderedPost.get(m.selectedBlogEntry, width); ok {
+		return content
+	}
+	
 	entry := m.blogEntries[m.selectedBlogEntry]
 	
 	// Render the markdown content using Glamour
-	renderedContent := renderMarkdownForDisplay(entry.Content, m.width)
+	renderedContent := renderMarkdownForDisplay(entry.Content, width)
 	
-	// Create header with title and date
-	header := fmt.Sprintf("📝 %s\n📅 %s\n\n", entry.Title, entry.Date)
-	
-	return contentStyle.Render(header + renderedContent)
-}
-
-func (m Model) getAboutContent() string {
-	markdownContent := `# 👋 About Arpan
-
-I'm a passionate software developer and tech enthusiast with a love for creating beautiful, functional applications. My journey in technology spans across various domains, always driven by curiosity and the desire to solve complex problems.
-
-## 🎓 Background & Education
-- **Computer Science and Engineering**
-- **Full-stack development** experience across multiple technologies
-- **Continuous learner**, always exploring new technologies and methodologies
-- **Active contributor** to open source projects and tech communities
-
-## 💻 Technical Expertise
-
-### 🌐 Frontend Development
-- **React, Next.js, Vue.js** - Modern JavaScript frameworks
-- **TypeScript** for type-safe development
-- **HTML5, CSS3, Sass/SCSS** for styling
-- **Responsive design** and accessibility best practices
-- **State management** with Redux, Zustand, Context API
-
-### ⚙️ Backend Development
-- **Go** - Systems programming and web services
-- **Node.js, Express.js** - JavaScript backend development
-- **Python, Django, FastAPI** - Rapid development and data processing
-- **RESTful APIs and GraphQL**
-- **Microservices architecture** and distributed systems
-
-### 🗄️ Database Technologies
-- **PostgreSQL, MySQL** - Relational databases
-- **MongoDB, Redis** - NoSQL solutions
-- **Database design** and optimization
-- **Data modeling** and migration strategies
-
-### ☁️ Cloud & DevOps
-- **AWS, Google Cloud Platform** - Cloud infrastructure
-- **Docker, Kubernetes** - Containerization and orchestration
-- **CI/CD pipelines** with GitHub Actions, GitLab CI
-- **Infrastructure as Code** with Terraform
-- **Monitoring and logging** solutions
-
-### 🛠️ Development Tools & Practices
-- **Git version control** and collaborative workflows
-- **Test-driven development (TDD)** and automated testing
-- **Code review processes** and pair programming
-- **Agile methodologies** and project management
-- **Performance optimization** and security best practices
-
-## 🌟 Philosophy & Approach
-
-I believe in writing clean, maintainable code that not only solves problems but is also a joy to work with. Every project, whether it's a complex enterprise application or a simple CLI tool, deserves attention to detail and thoughtful architecture.
-
-**My approach emphasizes:**
-- User-centered design and experience
-- Scalable and maintainable code architecture
-- Collaborative development and knowledge sharing
-- Continuous learning and adaptation to new technologies
-- Open source contribution and community building
-
-## 🚀 Current Focus & Interests
-- Building developer tools that improve productivity
-- Exploring systems programming with Go and Rust
-- Contributing to open source projects
-- Terminal applications and command-line interfaces
-- Modern web technologies and frameworks
-- Machine learning and AI applications
-- Mentoring junior developers and sharing knowledge
-
-## 🎯 Goals & Aspirations
-- Create impactful software that solves real-world problems
-- Build and maintain high-quality open source projects
-- Foster inclusive and collaborative development communities
-- Continue learning and staying current with technology trends
-- Share knowledge through writing, speaking, and mentoring
-
----
-
-When I'm not coding, you might find me exploring new technologies, contributing to open source projects, writing technical blogs, or engaging with the developer community. I'm always excited to learn something new and share that knowledge with others.
-
-**Let's build something amazing together!** 🎉`
-
-	// Render the markdown content using Glamour
-	renderedContent := renderMarkdownForDisplay(markdownContent, m.width)
+	// Create header with title, byline and tags
+	header := fmt.Sprintf("📝 %s\n%s\n", entry.Title, entry.Byline())
+	if len(entry.Tags) > 0 {
+		header += "🏷️  " + formatTags(entry.Tags) + "\n"
+	}
+	header += "\n"
 	
-	return contentStyle.Render(renderedContent)
+	content := contentStyle.Render(header + renderedContent)
+	m.renderedPost.set(m.selectedBlogEntry, width, content)
+	return content
 }
 
-func (m Model) getContactContent() string {
-	markdownContent := `# 📬 Get In Touch
-
-I'm always excited to connect with fellow developers, potential collaborators, or anyone interested in technology! Whether you want to discuss a project, share ideas, or just have a friendly chat about development, I'd love to hear from you.
-
-## 🔗 Find Me Online
-
-### 🐙 GitHub
-**[https://github.com/Arpan-206](https://github.com/Arpan-206)**
-- Check out my repositories and contributions
-- See my latest projects and code samples
-- Contribute to open source projects together
-- Star repositories you find interesting!
-
-### 💼 LinkedIn
-**[https://www.linkedin.com/in/arpan-pandey/](https://www.linkedin.com/in/arpan-pandey/)**
-- Professional background and experience
-- Connect for networking and opportunities
-- Endorse skills and get recommendations
-- Stay updated with my professional journey
-
-### 📧 Email
-**Best reached via LinkedIn**  
-For direct communication, please connect with me on LinkedIn first. I'm responsive and check messages regularly!
-
----
-
-## 💼 Professional Opportunities
-
-### 🤝 Open to Collaboration
-- Open source project contributions
-- Technical writing and documentation
-- Code reviews and pair programming sessions
-- Speaking at tech events and conferences
-- Mentoring and knowledge sharing
-
-### 💻 Freelance & Contract Work
-- Full-stack web application development
-- API design and backend services
-- Terminal applications and CLI tools
-- Code audits and technical consulting
-- DevOps and infrastructure setup
-
-### 🏢 Full-time Positions
-- Software Engineer / Senior Software Engineer
-- Full-stack Developer positions
-- Backend/Systems Engineer roles
-- DevOps Engineer opportunities
-- Technical Lead positions
-
----
-
-## 🎯 Areas of Interest
-
-### 🛠️ Technology Domains
-- Go, Rust, and systems programming
-- Modern JavaScript/TypeScript ecosystems
-- Cloud-native applications and microservices
-- Developer tooling and CLI applications
-- Database design and optimization
-- API development and integration
-
-### 🌍 Industry Sectors
-- Developer tools and productivity software
-- Financial technology (FinTech)
-- Healthcare technology solutions
-- Educational technology platforms
-- Open source and community-driven projects
-- Startups and innovative tech companies
-
-### 💡 Project Types
-- Greenfield projects with modern tech stacks
-- Legacy system modernization and migration
-- Performance optimization and scalability improvements
-- Integration projects and API development
-- Automation and workflow improvement tools
-
----
-
-## 🤔 What I'm Looking For
-
-### 🎯 In Collaborations
-- Passionate and skilled team members
-- Projects that make a positive impact
-- Opportunities to learn and grow
-- Respectful and inclusive work environments
-- Clear communication and shared goals
-
-### 💪 In Roles
-- Challenging technical problems to solve
-- Opportunities for professional growth
-- Mentorship and knowledge sharing culture
-- Work-life balance and flexibility
-- Competitive compensation and benefits
-
----
-
-## 📅 Let's Connect!
-
-Whether you're interested in:
-- Discussing potential collaborations
-- Exploring job opportunities
-- Getting technical advice or mentorship
-- Sharing ideas about technology and development
-- Just having a friendly chat about coding
-
-I'm always happy to connect! The best way to reach me is through LinkedIn, where I'm active and responsive. Let's build something amazing together!
-
-🚀 **Looking forward to hearing from you!** 🎉
-
----
-
-**P.S.** If you enjoyed this terminal portfolio, feel free to star it on GitHub or share it with others who might appreciate terminal-based applications. Your support means a lot! ⭐`
+// readingStatus summarises the open post for the footer
+func (m Model) readingStatus() string {
+	if m.selectedBlogEntry >= len(m.blogEntries) {
+		return "Reading blog post"
+	}
 
-	// Render the markdown content using Glamour
-	renderedContent := renderMarkdownForDisplay(markdownContent, m.width)
-	
-	return contentStyle.Render(renderedContent)
+	entry := m.blogEntries[m.selectedBlogEntry]
+	parts := []string{entry.ReadTime}
+	if entry.Author != "" {
+		parts = append(parts, "by "+entry.Author)
+	}
+	if len(entry.Tags) > 0 {
+		parts = append(parts, formatTags(entry.Tags))
+	}
+	if parts[0] == "" {
+		parts[0] = "Reading blog post"
+	}
+	return strings.Join(parts, " • ")
 }
 
 func (m Model) renderFooter() string {
-	var helpText string
+	var status string
+	var bindings []key.Binding
 	
-	if m.viewingBlogEntry {
-		helpText = "📖 Reading blog post • ↑/↓ PgUp/PgDn to scroll • Backspace to return • q/Ctrl+C to quit"
-	} else {
-		switch m.currentPage {
-		case BlogPage:
-			helpText = "📚 Blog posts • ↑/↓ navigate • Enter to read • ←/→ change page • q/Ctrl+C to quit"
-		default:
-			helpText = "🧭 Portfolio navigation • ←/→ navigate pages • ↑/↓ scroll content • q/Ctrl+C to quit"
+	if m.showingHelp {
+		status = "❓ Keyboard shortcuts"
+		if m.helpOverflow() > 0 {
+			bindings = append(bindings, pairBinding(m.keys.Up, m.keys.Down, "scroll"))
 		}
+		bindings = append(bindings, pairBinding(m.keys.Help, m.keys.Cancel, "close"))
+	} else if m.searching {
+		status = fmt.Sprintf("🔍 Search • %d results", len(m.searchResults))
+		bindings = []key.Binding{
+			pairBinding(m.keys.PrevResult, m.keys.NextResult, "select"),
+			withDesc(m.keys.Select, "open"),
+			m.keys.Cancel,
+		}
+	} else {
+		status, bindings = m.views[m.currentPage].Help(&m)
 	}
 	
+	helpText := m.help.ShortHelpView(bindings)
+	if status != "" && helpText != "" {
+		helpText = status + " • " + helpText
+	} else if status != "" {
+		helpText = status
+	}
+	if m.notice != "" {
+		helpText = noticeStyle.Render(m.notice) + " • " + helpText
+	}
+
+	// Keep the footer on one line; the viewport only leaves room for one
+	if m.width > 4 {
+		helpText = ansi.Truncate(helpText, m.width-4, "…")
+	}
+
 	return footerStyle.Render(helpText)
 }
+
+// newHelp returns the help model that draws key hints in the footer
+func newHelp() help.Model {
+	h := help.New()
+	keyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#909090")).
+		Background(lipgloss.Color("#1a1a1a"))
+	descStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#626262")).
+		Background(lipgloss.Color("#1a1a1a"))
+
+	h.Styles.ShortKey = keyStyle
+	h.Styles.ShortDesc = descStyle
+	h.Styles.ShortSeparator = descStyle
+	h.Styles.Ellipsis = descStyle
+	h.Styles.FullKey = keyStyle
+	h.Styles.FullDesc = descStyle
+	h.Styles.FullSeparator = descStyle
+	return h
+}
@@ -0,0 +1,155 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// IDs of the built-in sections
+const (
+	ProjectsPageID = "projects"
+	BlogPageID     = "blog"
+)
+
+// Page is a top-level page of the portfolio. A page is created for each
+// session, so it may keep state of its own. Every method is given the
+// session's Model, whose exported methods report the terminal size.
+type Page interface {
+	// Title names the page in the navbar when content/pages has no file
+	// for it
+	Title() string
+
+	// View renders the page body shown in the viewport
+	View(m *Model) string
+
+	// Update handles a key the portfolio does not use itself, such as
+	// "up", "enter" or the sequence "g g". Match it against the session's
+	// bindings with KeyMatches. It reports whether the key was used;
+	// unused keys scroll the viewport or switch pages.
+	Update(m *Model, k string) (bool, tea.Cmd)
+
+	// Help returns a status and the bindings hinted at in the footer
+	Help(m *Model) (string, []key.Binding)
+}
+
+// PageModel is implemented by pages built around a Bubble Tea model of
+// their own, such as a form or a spinner. Init is called when the session
+// starts, and HandleMsg receives every message other than key presses,
+// including the results of the page's own commands.
+type PageModel interface {
+	Init() tea.Cmd
+	HandleMsg(msg tea.Msg) tea.Cmd
+}
+
+// registeredPage is a page added with RegisterPage
+type registeredPage struct {
+	id      string
+	newPage func() Page
+}
+
+// registry lists the registered pages in the order they were added
+var registry []registeredPage
+
+func init() {
+	RegisterPage(ProjectsPageID, func() Page { return projectsPage{} })
+	RegisterPage(BlogPageID, func() Page { return blogPage{} })
+}
+
+// RegisterPage makes newPage create the page with the given ID for each
+// session, replacing any page already registered under it. Call it from an
+// init function, before the server starts.
+//
+// The page appears in the navbar when content/pages has a file named after
+// the ID, whose frontmatter sets its title, position and icon. Without one
+// it can only be reached by code, such as a link from another page.
+func RegisterPage(id string, newPage func() Page) {
+	for i, r := range registry {
+		if r.id == id {
+			registry[i].newPage = newPage
+			return
+		}
+	}
+	registry = append(registry, registeredPage{id: id, newPage: newPage})
+}
+
+// sessionPages returns the pages for a new session with their navbar
+// entries. Registered pages render the entries with the same ID, and the
+// rest are shown as markdown. Registered pages without an entry are added
+// hidden.
+func sessionPages() ([]ContentPage, []Page) {
+	entries := LoadPages()
+	views := make([]Page, len(entries))
+
+	for i, entry := range entries {
+		views[i] = markdownPage{entry}
+	}
+	for _, r := range registry {
+		page := r.newPage()
+		if i := findPage(entries, r.id); i >= 0 {
+			views[i] = page
+			continue
+		}
+		entries = append(entries, ContentPage{ID: r.id, Title: page.Title(), Hidden: true})
+		views = append(views, page)
+	}
+	return entries, views
+}
+
+// findPage returns the index of the page with the given ID, or -1
+func findPage(pages []ContentPage, id string) int {
+	for i, page := range pages {
+		if page.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// RenderPage renders markdown the way the portfolio's own pages are drawn,
+// for pages that show markdown
+func RenderPage(markdown string, width int) string {
+	return contentStyle.Render(renderMarkdownForDisplay(markdown, width))
+}
+
+// pageNavKey reports whether k switches pages, for pages to swallow while
+// they show something nested such as a post
+func (m Model) pageNavKey(k string) bool {
+	return KeyMatches(k, m.keys.PrevPage, m.keys.NextPage)
+}
+
+// markdownPage is a page from content/pages that shows its markdown
+type markdownPage struct {
+	page ContentPage
+}
+
+func (p markdownPage) Title() string {
+	return p.page.Title
+}
+
+func (p markdownPage) View(m *Model) string {
+	return RenderPage(p.page.Content, m.Width())
+}
+
+func (p markdownPage) Update(m *Model, k string) (bool, tea.Cmd) {
+	return false, nil
+}
+
+func (p markdownPage) Help(m *Model) (string, []key.Binding) {
+	return "🧭 Portfolio navigation", m.keys.ShortHelp()
+}
+
+// Width returns the width of the terminal
+func (m Model) Width() int {
+	return m.width
+}
+
+// Height returns the height of the terminal
+func (m Model) Height() int {
+	return m.height
+}
+
+// Redraw renders the current page again and scrolls back to its top, for
+// pages whose content changed
+func (m *Model) Redraw() {
+	m.updateViewportContent()
+}
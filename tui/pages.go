@@ -0,0 +1,352 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// screenshotWidth is how many characters wide project screenshots are
+// drawn
+const screenshotWidth = 60
+
+// The page sources below are shared by the terminal UI and the static site
+// generator, so both always render the same text.
+
+// ContentPage is a top-level page from content/pages: where it sits in the
+// navbar and, for pages that show markdown, its text
+type ContentPage struct {
+	ID       string // file name without .md; also the page's URL path
+	Title    string
+	Icon     string
+	Order    int
+	Hidden   bool   // left out of the navbar and menus
+	Content  string // markdown; unused by registered pages such as Blog
+	FilePath string
+}
+
+// NavTitle returns the page title with its icon, for navigation bars
+func (p ContentPage) NavTitle() string {
+	if p.Icon == "" {
+		return p.Title
+	}
+	return p.Icon + " " + p.Title
+}
+
+// pagesDir returns the directory top-level pages are read from
+func pagesDir() string {
+	return filepath.Join("content", "pages")
+}
+
+// readPageFile reads a top-level page from a markdown file, filling in
+// the profile placeholders
+func readPageFile(filePath string, profile Profile) (ContentPage, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return ContentPage{}, err
+	}
+
+	fields, body, err := frontMatterFields(string(content))
+	if err != nil {
+		return ContentPage{}, fmt.Errorf("%s: %w", filePath, err)
+	}
+
+	name := filepath.Base(filePath)
+	page := ContentPage{
+		ID:       strings.TrimSuffix(name, filepath.Ext(name)),
+		Title:    fields["title"],
+		Icon:     fields["icon"],
+		Content:  strings.TrimSpace(expandProfile(body, profile)),
+		FilePath: filePath,
+	}
+
+	if page.Title == "" {
+		return ContentPage{}, fmt.Errorf("%s: missing title", filePath)
+	}
+	if order := fields["order"]; order != "" {
+		if page.Order, err = strconv.Atoi(order); err != nil {
+			return ContentPage{}, fmt.Errorf("%s: order must be a whole number, got %q", filePath, order)
+		}
+	}
+	switch visibility := fields["visibility"]; visibility {
+	case "", "visible":
+	case "hidden":
+		page.Hidden = true
+	default:
+		return ContentPage{}, fmt.Errorf("%s: visibility must be visible or hidden, got %q", filePath, visibility)
+	}
+
+	return page, nil
+}
+
+// LoadPages returns the top-level pages from content/pages in navbar
+// order, falling back to the built-in pages when none can be read. The
+// Projects and Blog sections are always included, hidden if they have no
+// page file, so links to projects and posts keep working.
+func LoadPages() []ContentPage {
+	files, err := os.ReadDir(pagesDir())
+	if err != nil {
+		return getFallbackPages()
+	}
+
+	profile := GetProfile()
+	var pages []ContentPage
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".md") {
+			continue
+		}
+
+		page, err := readPageFile(filepath.Join(pagesDir(), file.Name()), profile)
+		if err != nil {
+			log.Warn("Skipping page", "error", err)
+			continue
+		}
+		pages = append(pages, page)
+	}
+
+	if len(pages) == 0 {
+		return getFallbackPages()
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		if pages[i].Order != pages[j].Order {
+			return pages[i].Order < pages[j].Order
+		}
+		return pages[i].Title < pages[j].Title
+	})
+
+	for _, section := range []ContentPage{
+		{ID: ProjectsPageID, Title: "Projects", Hidden: true},
+		{ID: BlogPageID, Title: "Blog", Hidden: true},
+	} {
+		if findPage(pages, section.ID) < 0 {
+			pages = append(pages, section)
+		}
+	}
+	return pages
+}
+
+// Content is everything the portfolio publishes, for servers that load it
+// once at startup rather than on every request
+type Content struct {
+	Profile  Profile
+	Pages    []ContentPage
+	Posts    []BlogPost
+	Projects []Project
+}
+
+// LoadContent reads the profile, pages, blog posts and projects from disk
+func LoadContent() Content {
+	return Content{
+		Profile:  GetProfile(),
+		Pages:    LoadPages(),
+		Posts:    LoadBlogPosts(),
+		Projects: LoadProjects(),
+	}
+}
+
+// getFallbackPages returns the built-in pages as fallback
+func getFallbackPages() []ContentPage {
+	profile := GetProfile()
+	return []ContentPage{
+		{ID: "home", Title: "Home", Order: 1, Content: homeMarkdown(profile)},
+		{ID: ProjectsPageID, Title: "Projects", Order: 2},
+		{ID: BlogPageID, Title: "Blog", Order: 3},
+		{ID: "contact", Title: "Contact", Order: 4, Content: contactMarkdown(profile)},
+	}
+}
+
+// Link is a labelled URL, such as one of the author's profiles or a
+// project's demo
+type Link struct {
+	Label string
+	URL   string
+}
+
+// Profile holds the facts about the author that appear on several pages,
+// in the feeds and in the Finger responder. It is read from
+// content/profile.md so they all change together.
+type Profile struct {
+	Name     string
+	Login    string
+	Tagline  string
+	Status   string
+	Email    string
+	GitHub   string
+	LinkedIn string
+}
+
+// Links returns the profile's links in display order, leaving out any
+// that are not set
+func (p Profile) Links() []Link {
+	var links []Link
+	for _, link := range []Link{
+		{Label: "GitHub", URL: p.GitHub},
+		{Label: "LinkedIn", URL: p.LinkedIn},
+		{Label: "Email", URL: "mailto:" + p.Email},
+	} {
+		if link.URL != "" && link.URL != "mailto:" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// profilePath returns the file the author's profile is read from
+func profilePath() string {
+	return filepath.Join("content", "profile.md")
+}
+
+// GetProfile returns the author's profile from the frontmatter of
+// content/profile.md, or a placeholder when it cannot be read
+func GetProfile() Profile {
+	content, err := os.ReadFile(profilePath())
+	if err != nil {
+		log.Warn("Using placeholder profile", "error", err)
+		return Profile{Name: "Portfolio Author", Login: "author"}
+	}
+
+	fields, _, err := frontMatterFields(string(content))
+	if err != nil {
+		log.Warn("Using placeholder profile", "error", fmt.Errorf("%s: %w", profilePath(), err))
+		return Profile{Name: "Portfolio Author", Login: "author"}
+	}
+
+	return Profile{
+		Name:     fields["name"],
+		Login:    fields["login"],
+		Tagline:  fields["tagline"],
+		Status:   fields["status"],
+		Email:    fields["email"],
+		GitHub:   fields["github"],
+		LinkedIn: fields["linkedin"],
+	}
+}
+
+// expandProfile fills in the profile placeholders, such as {{name}} or
+// {{github}}, that pages use instead of repeating the author's details
+func expandProfile(markdown string, profile Profile) string {
+	return strings.NewReplacer(
+		"{{name}}", profile.Name,
+		"{{login}}", profile.Login,
+		"{{tagline}}", profile.Tagline,
+		"{{status}}", profile.Status,
+		"{{email}}", profile.Email,
+		"{{github}}", profile.GitHub,
+		"{{linkedin}}", profile.LinkedIn,
+	).Replace(markdown)
+}
+
+// homeMarkdown returns the built-in home page, shown when content/pages
+// cannot be read
+func homeMarkdown(profile Profile) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# 👋 Hi, I'm %s\n", profile.Name)
+	if profile.Tagline != "" {
+		fmt.Fprintf(&b, "\n%s.\n", profile.Tagline)
+	}
+	return b.String()
+}
+
+// contactMarkdown returns the built-in contact page, listing the
+// profile's links
+func contactMarkdown(profile Profile) string {
+	var b strings.Builder
+	b.WriteString("# 📬 Get In Touch\n\n")
+	for _, link := range profile.Links() {
+		fmt.Fprintf(&b, "- **%s:** %s\n", link.Label, link.URL)
+	}
+	return b.String()
+}
+
+// ProjectsMarkdown returns the markdown source of the projects page,
+// ending with a link to the author's GitHub profile when there is one
+func ProjectsMarkdown(projects []Project, profile Profile) string {
+	var markdownContent strings.Builder
+	markdownContent.WriteString("# 🛠️ Featured Projects\n\n")
+	markdownContent.WriteString("Here are some of my notable projects and contributions:\n\n")
+
+	for i, project := range projects {
+		markdownContent.WriteString(fmt.Sprintf("## %d. %s\n\n", i+1, project.Name))
+		markdownContent.WriteString(projectDetailsMarkdown(project))
+		markdownContent.WriteString("\n---\n\n")
+	}
+
+	if profile.GitHub != "" {
+		markdownContent.WriteString("## 🔗 Links\n\n")
+		markdownContent.WriteString("Check out my GitHub profile for complete project listings, source code, and detailed documentation:\n\n")
+		markdownContent.WriteString(fmt.Sprintf("**GitHub:** [%s](%s)\n\n", profile.GitHub, profile.GitHub))
+	}
+	markdownContent.WriteString("🚀 Always working on something new and exciting!\n")
+
+	return markdownContent.String()
+}
+
+// ProjectMarkdown returns the markdown source for a single project: its
+// summary, long-form write-up, screenshots as ASCII art, and links
+func ProjectMarkdown(project Project) string {
+	var markdownContent strings.Builder
+	markdownContent.WriteString(fmt.Sprintf("# %s\n\n", project.Name))
+	markdownContent.WriteString(projectDetailsMarkdown(project))
+
+	if project.Details != "" {
+		markdownContent.WriteString("\n" + strings.TrimSpace(project.Details) + "\n")
+	}
+
+	if len(project.Screenshots) > 0 {
+		markdownContent.WriteString("\n## 📸 Screenshots\n\n")
+		for _, screenshot := range project.Screenshots {
+			art, err := ImageToASCII(filepath.Join(projectsDir(), screenshot), screenshotWidth)
+			if err != nil {
+				log.Warn("Skipping project screenshot", "project", project.Name, "error", err)
+				continue
+			}
+			markdownContent.WriteString("```\n" + art + "```\n\n")
+		}
+	}
+
+	if len(project.Links) > 0 {
+		markdownContent.WriteString("\n## 🔗 Links\n\n")
+		for _, link := range project.Links {
+			markdownContent.WriteString(fmt.Sprintf("- **%s:** [%s](%s)\n", link.Label, link.URL, link.URL))
+		}
+	}
+
+	return markdownContent.String()
+}
+
+// projectDetailsMarkdown describes a project's technologies, features,
+// status and repository
+func projectDetailsMarkdown(project Project) string {
+	var markdownContent strings.Builder
+	markdownContent.WriteString(fmt.Sprintf("**Description:** %s\n\n", project.Description))
+
+	// Tech stack
+	markdownContent.WriteString("**Technologies:**\n")
+	for _, tech := range project.Tech {
+		markdownContent.WriteString(fmt.Sprintf("- %s\n", tech))
+	}
+	markdownContent.WriteString("\n")
+
+	// Features
+	if len(project.Features) > 0 {
+		markdownContent.WriteString("**Key Features:**\n")
+		for _, feature := range project.Features {
+			markdownContent.WriteString(fmt.Sprintf("- %s\n", feature))
+		}
+		markdownContent.WriteString("\n")
+	}
+
+	// Status and URL
+	markdownContent.WriteString(fmt.Sprintf("**Status:** %s\n", project.Status))
+	if project.URL != "" {
+		markdownContent.WriteString(fmt.Sprintf("**Repository:** [%s](%s)\n", project.URL, project.URL))
+	}
+
+	return markdownContent.String()
+}
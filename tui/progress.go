@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// readingProgress renders how far through the open post the reader is as a
+// bar and a percentage
+func (m Model) readingProgress() string {
+	percent := m.viewport.ScrollPercent()
+	return fmt.Sprintf("%s %3.0f%%", m.progress.ViewAs(percent), percent*100)
+}
+
+// rememberPosition records the scroll position of the open post, and
+// marks the post read once the reader reaches the end. A post that fits on
+// screen is marked read once it has been open for shortPostReadTime.
+func (m Model) rememberPosition() {
+	if !m.viewingBlogEntry || m.visitors == nil || m.selectedBlogEntry >= len(m.blogEntries) {
+		return
+	}
+	id := m.blogEntries[m.selectedBlogEntry].ID
+	position := m.viewport.ScrollPercent()
+	m.visitors.SetPosition(m.visitorID, id, position)
+	if m.viewport.TotalLineCount() <= m.viewport.Height {
+		if time.Since(m.postOpened) >= shortPostReadTime {
+			m.visitors.MarkRead(m.visitorID, id)
+		}
+		return
+	}
+	if position >= readThreshold {
+		m.visitors.MarkRead(m.visitorID, id)
+	}
+}
+
+// restorePosition scrolls the open post back to where the visitor left it
+// and notes when it was opened
+func (m *Model) restorePosition() {
+	m.postOpened = time.Now()
+	if m.selectedBlogEntry >= len(m.blogEntries) {
+		return
+	}
+	id := m.blogEntries[m.selectedBlogEntry].ID
+
+	position, ok := m.visitors.Position(m.visitorID, id)
+	if !ok || position <= 0 {
+		return
+	}
+
+	m.scrollToFraction(position)
+	m.logger.Info("resume post", "id", id, "position", fmt.Sprintf("%.0f%%", position*100))
+}
+
+// scrollToFraction scrolls the viewport to a point between the top (0) and
+// the bottom (1) of its content
+func (m *Model) scrollToFraction(fraction float64) {
+	scrollable := max(0, m.viewport.TotalLineCount()-m.viewport.Height)
+	m.viewport.SetYOffset(int(math.Round(fraction * float64(scrollable))))
+}
@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// Project represents a project entry
+type Project struct {
+	Name        string
+	Description string
+	Tech        []string
+	Features    []string
+	Status      string
+	URL         string
+	Order       int      // position in the list; projects without one come last
+	Details     string   // long-form markdown shown in the detail view
+	Screenshots []string // images relative to content/projects, drawn as ASCII
+	Links       []Link
+	FilePath    string
+}
+
+// projectsDir returns the directory projects and their assets are read from
+func projectsDir() string {
+	return filepath.Join("content", "projects")
+}
+
+// readProjectFile reads a project from a markdown file. The frontmatter
+// holds the facts shown on the project card and the body is the long-form
+// write-up. It reports false for projects that are not featured.
+func readProjectFile(filePath string) (Project, bool, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return Project{}, false, err
+	}
+
+	fields, body, err := frontMatterFields(string(content))
+	if err != nil {
+		return Project{}, false, fmt.Errorf("%s: %w", filePath, err)
+	}
+	if fields["featured"] != "true" {
+		return Project{}, false, nil
+	}
+
+	project := Project{
+		Name:        fields["name"],
+		Description: fields["description"],
+		Tech:        parseList(fields["tech"]),
+		Features:    parseList(fields["features"]),
+		Status:      fields["status"],
+		URL:         fields["url"],
+		Details:     strings.TrimSpace(body),
+		Screenshots: parseList(fields["screenshots"]),
+		FilePath:    filePath,
+	}
+
+	// Links are written as "Label: URL"
+	for _, link := range parseList(fields["links"]) {
+		label, url, ok := strings.Cut(link, ": ")
+		if !ok {
+			label, url = link, link
+		}
+		project.Links = append(project.Links, Link{Label: label, URL: url})
+	}
+
+	if project.Name == "" {
+		return Project{}, false, fmt.Errorf("%s: missing name", filePath)
+	}
+	if project.Status == "" {
+		return Project{}, false, fmt.Errorf("%s: missing status", filePath)
+	}
+	if order := fields["order"]; order != "" {
+		project.Order, err = strconv.Atoi(order)
+		if err != nil || project.Order < 1 {
+			return Project{}, false, fmt.Errorf("%s: order must be a positive whole number, got %q", filePath, order)
+		}
+	}
+
+	return project, true, nil
+}
+
+// LoadProjects returns the featured projects from content/projects in
+// order, falling back to the built-in projects when none can be read
+func LoadProjects() []Project {
+	files, err := os.ReadDir(projectsDir())
+	if err != nil {
+		return getFallbackProjects()
+	}
+
+	var projects []Project
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".md") {
+			continue
+		}
+
+		project, featured, err := readProjectFile(filepath.Join(projectsDir(), file.Name()))
+		if err != nil {
+			log.Warn("Skipping project", "error", err)
+			continue
+		}
+		if featured {
+			projects = append(projects, project)
+		}
+	}
+
+	if len(projects) == 0 {
+		return getFallbackProjects()
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		return projectLess(projects[i], projects[j])
+	})
+	return projects
+}
+
+// projectLess orders projects by their order field, then by name, with
+// unordered projects after ordered ones
+func projectLess(a, b Project) bool {
+	if a.Order != b.Order {
+		if a.Order == 0 || b.Order == 0 {
+			return b.Order == 0
+		}
+		return a.Order < b.Order
+	}
+	return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+}
+
+// getFallbackProjects returns hardcoded projects as fallback
+func getFallbackProjects() []Project {
+	return []Project{
+		{
+			Name:        "Terminal Portfolio",
+			Description: "A beautiful terminal-based portfolio built with Bubble Tea",
+			Tech:        []string{"Go", "Bubble Tea", "Lipgloss", "SSH"},
+			Features:    []string{"Responsive design", "Keyboard navigation", "Smooth scrolling", "SSH server integration"},
+			Status:      "Active",
+			URL:         "https://github.com/Arpan-206/terminal-portfolio",
+		},
+		{
+			Name:        "Full-Stack Web Applications",
+			Description: "Modern web applications using cutting-edge frameworks",
+			Tech:        []string{"React", "Next.js", "Node.js", "PostgreSQL"},
+			Features:    []string{"Real-time updates", "Responsive UI", "RESTful APIs", "Authentication"},
+			Status:      "Active",
+			URL:         "https://github.com/Arpan-206",
+		},
+		{
+			Name:        "DevOps Automation Tools",
+			Description: "Scripts and tools for automating development workflows",
+			Tech:        []string{"Python", "Bash", "Docker", "GitHub Actions"},
+			Features:    []string{"CI/CD pipelines", "Automated testing", "Deployment scripts", "Infrastructure as Code"},
+			Status:      "Active",
+			URL:         "https://github.com/Arpan-206",
+		},
+		{
+			Name:        "Machine Learning Projects",
+			Description: "AI/ML applications solving real-world problems",
+			Tech:        []string{"Python", "TensorFlow", "PyTorch", "scikit-learn"},
+			Features:    []string{"Natural language processing", "Computer vision", "Data analysis", "Model deployment"},
+			Status:      "Active",
+			URL:         "https://github.com/Arpan-206",
+		},
+		{
+			Name:        "Mobile Applications",
+			Description: "Cross-platform mobile apps with native performance",
+			Tech:        []string{"React Native", "Flutter", "Firebase", "SQLite"},
+			Features:    []string{"Offline-first", "Real-time sync", "Push notifications", "Cross-platform UI"},
+			Status:      "Active",
+			URL:         "https://github.com/Arpan-206",
+		},
+		{
+			Name:        "Open Source Contributions",
+			Description: "Contributing to various open source projects in the community",
+			Tech:        []string{"Go", "JavaScript", "Python", "Rust"},
+			Features:    []string{"Bug fixes", "New features", "Documentation", "Community support"},
+			Status:      "Ongoing",
+			URL:         "https://github.com/Arpan-206",
+		},
+	}
+}
@@ -0,0 +1,313 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectSort is the order the projects list is shown in
+type projectSort int
+
+const (
+	sortByOrder projectSort = iota
+	sortByName
+	sortByStatus
+)
+
+func (s projectSort) String() string {
+	switch s {
+	case sortByName:
+		return "name"
+	case sortByStatus:
+		return "status"
+	default:
+		return "order"
+	}
+}
+
+// knownStatuses lists project statuses in the order they sort; any others
+// follow alphabetically
+var knownStatuses = []string{"Active", "Ongoing", "Archived"}
+
+// filterChip is one tech or status the projects list can be filtered by
+type filterChip struct {
+	Kind  string // "tech" or "status"
+	Value string
+	Count int
+}
+
+var offChipStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#7D56F4")).
+	PaddingLeft(1).
+	PaddingRight(1)
+
+// statusRank places status among knownStatuses, with unknown statuses last
+func statusRank(status string) int {
+	for i, s := range knownStatuses {
+		if strings.EqualFold(s, status) {
+			return i
+		}
+	}
+	return len(knownStatuses)
+}
+
+// projectChips returns a chip for every status used by projects and then
+// every technology, most used first
+func projectChips(projects []Project) []filterChip {
+	techCounts := make(map[string]int)
+	statusCounts := make(map[string]int)
+	for _, project := range projects {
+		for _, tech := range project.Tech {
+			techCounts[tech]++
+		}
+		statusCounts[project.Status]++
+	}
+
+	var tech, status []filterChip
+	for value, count := range techCounts {
+		tech = append(tech, filterChip{Kind: "tech", Value: value, Count: count})
+	}
+	for value, count := range statusCounts {
+		status = append(status, filterChip{Kind: "status", Value: value, Count: count})
+	}
+
+	sort.Slice(tech, func(i, j int) bool {
+		if tech[i].Count != tech[j].Count {
+			return tech[i].Count > tech[j].Count
+		}
+		return strings.ToLower(tech[i].Value) < strings.ToLower(tech[j].Value)
+	})
+	sort.Slice(status, func(i, j int) bool {
+		a, b := statusRank(status[i].Value), statusRank(status[j].Value)
+		if a != b {
+			return a < b
+		}
+		return status[i].Value < status[j].Value
+	})
+	return append(status, tech...)
+}
+
+// chipActive reports whether the chip is one of the current filters
+func (m Model) chipActive(chip filterChip) bool {
+	filters := m.techFilters
+	if chip.Kind == "status" {
+		filters = m.statusFilters
+	}
+	for _, f := range filters {
+		if f == chip.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// toggleChip adds the chip to the filters, or removes it if it is there
+func (m *Model) toggleChip(chip filterChip) {
+	filters := &m.techFilters
+	if chip.Kind == "status" {
+		filters = &m.statusFilters
+	}
+
+	if m.chipActive(chip) {
+		var kept []string
+		for _, f := range *filters {
+			if f != chip.Value {
+				kept = append(kept, f)
+			}
+		}
+		*filters = kept
+	} else {
+		*filters = append(*filters, chip.Value)
+		m.logger.Info("project filter", chip.Kind, chip.Value)
+	}
+	m.selectFirstProject()
+}
+
+// clearProjectFilters shows every project again
+func (m *Model) clearProjectFilters() {
+	m.techFilters = nil
+	m.statusFilters = nil
+	m.selectFirstProject()
+}
+
+// projectMatches reports whether project has any of the chosen statuses
+// and uses any of the chosen technologies
+func (m Model) projectMatches(project Project) bool {
+	if len(m.techFilters) > 0 {
+		found := false
+		for _, tech := range project.Tech {
+			for _, f := range m.techFilters {
+				if tech == f {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(m.statusFilters) > 0 {
+		for _, f := range m.statusFilters {
+			if project.Status == f {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// visibleProjects returns the indexes of the projects that pass the
+// filters, in the chosen sort order
+func (m Model) visibleProjects() []int {
+	var visible []int
+	for i, project := range m.projects {
+		if m.projectMatches(project) {
+			visible = append(visible, i)
+		}
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		a, b := m.projects[visible[i]], m.projects[visible[j]]
+		switch m.projectSort {
+		case sortByName:
+			return strings.ToLower(a.Name) < strings.ToLower(b.Name)
+		case sortByStatus:
+			if ra, rb := statusRank(a.Status), statusRank(b.Status); ra != rb {
+				return ra < rb
+			}
+			if a.Status != b.Status {
+				return a.Status < b.Status
+			}
+		}
+		return projectLess(a, b)
+	})
+	return visible
+}
+
+// moveProjectSelection moves the selected card by delta among the visible
+// projects, clamping at either end
+func (m *Model) moveProjectSelection(delta int) {
+	visible := m.visibleProjects()
+	if len(visible) == 0 {
+		return
+	}
+
+	pos := 0
+	for i, index := range visible {
+		if index == m.selectedProject {
+			pos = i
+		}
+	}
+	pos = max(0, min(len(visible)-1, pos+delta))
+	m.selectedProject = visible[pos]
+}
+
+// selectFirstProject selects the first card after the list changes
+func (m *Model) selectFirstProject() {
+	if visible := m.visibleProjects(); len(visible) > 0 {
+		m.selectedProject = visible[0]
+	}
+}
+
+// cycleProjectSort switches the projects list to the next sort order
+func (m *Model) cycleProjectSort() {
+	m.projectSort = (m.projectSort + 1) % 3
+	m.selectFirstProject()
+}
+
+// updateProjectFilters handles keys in the project filters view,
+// reporting whether the key was used
+func (m *Model) updateProjectFilters(k string) bool {
+	chips := projectChips(m.projects)
+
+	switch {
+	case KeyMatches(k, m.keys.Up):
+		if m.selectedChip > 0 {
+			m.selectedChip--
+		}
+
+	case KeyMatches(k, m.keys.Down):
+		if m.selectedChip < len(chips)-1 {
+			m.selectedChip++
+		}
+
+	case KeyMatches(k, m.keys.Toggle):
+		if m.selectedChip < len(chips) {
+			m.toggleChip(chips[m.selectedChip])
+		}
+
+	// Esc closes the filters here rather than clearing them
+	case KeyMatches(k, m.keys.Back, m.keys.Cancel, m.keys.Filter):
+		m.viewingFilters = false
+
+	case KeyMatches(k, m.keys.ClearFilter):
+		m.clearProjectFilters()
+
+	default:
+		return false
+	}
+
+	m.updateViewportContent()
+	return true
+}
+
+// getProjectFiltersContent lists every technology and status as a chip
+// that can be toggled
+func (m Model) getProjectFiltersContent() string {
+	chips := projectChips(m.projects)
+
+	var lines []string
+	kind := ""
+	for i, chip := range chips {
+		if chip.Kind != kind {
+			kind = chip.Kind
+			heading := "🧰 Technology"
+			if kind == "status" {
+				heading = "📌 Status"
+			}
+			if len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			lines = append(lines, heading, "")
+		}
+
+		style := offChipStyle
+		if m.chipActive(chip) {
+			style = tagChipStyle
+		}
+		line := fmt.Sprintf("%s %d", style.Render(chip.Value), chip.Count)
+
+		if i == m.selectedChip {
+			lines = append(lines, tagStyle.Render("▸")+" "+line)
+		} else {
+			lines = append(lines, "  "+line)
+		}
+	}
+
+	header := fmt.Sprintf("🔎 Filter projects\n\nUse %s to choose, %s to toggle, %s to clear.\n",
+		keyHint(pairBinding(m.keys.Up, m.keys.Down, "")), keyHint(m.keys.Toggle),
+		keyHint(without(m.keys.ClearFilter, m.keys.Back, m.keys.Cancel, m.keys.Filter)))
+	header += "Projects must have one of the chosen statuses and use one of the chosen technologies.\n\n"
+	return contentStyle.Render(header + strings.Join(lines, "\n"))
+}
+
+// projectFilterChips renders the active filters and sort order for the
+// footer
+func (m Model) projectFilterChips() string {
+	var chips []string
+	for _, tech := range m.techFilters {
+		chips = append(chips, tagChipStyle.Render(tech))
+	}
+	for _, status := range m.statusFilters {
+		chips = append(chips, tagChipStyle.Render(status))
+	}
+	if m.projectSort != sortByOrder {
+		chips = append(chips, "sorted by "+m.projectSort.String())
+	}
+	return strings.Join(chips, " ")
+}
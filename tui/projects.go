@@ -0,0 +1,159 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// getProjectsContent lists the projects as selectable cards
+func (m Model) getProjectsContent() string {
+	var cards []string
+
+	for _, i := range m.visibleProjects() {
+		project := m.projects[i]
+		cardContent := fmt.Sprintf("🚀 %s\n\n%s\n\n🧰 %s\n📌 %s",
+			project.Name,
+			project.Description,
+			strings.Join(project.Tech, ", "),
+			project.Status)
+
+		if i == m.selectedProject {
+			cards = append(cards, selectedCardStyle.Render(cardContent))
+		} else {
+			cards = append(cards, cardStyle.Render(cardContent))
+		}
+	}
+
+	header := contentStyle.Render(fmt.Sprintf("🛠️ Featured Projects\n\nUse %s to choose a project, %s for details, %s to filter, %s to sort\n",
+		keyHint(pairBinding(m.keys.Up, m.keys.Down, "")), keyHint(m.keys.Select), keyHint(m.keys.Filter), keyHint(m.keys.Sort)))
+	if len(cards) == 0 {
+		return header + "\n" + contentStyle.Render(fmt.Sprintf("No projects match these filters. Press %s to clear them.", keyHint(m.keys.ClearFilter)))
+	}
+	return header + "\n" + strings.Join(cards, "\n")
+}
+
+// getProjectDetailContent renders the selected project's full write-up
+func (m Model) getProjectDetailContent() string {
+	if m.selectedProject >= len(m.projects) {
+		return contentStyle.Render("Project not found")
+	}
+
+	// Screenshots are converted to ASCII art as the markdown is built, so
+	// the result is kept for redraws
+	if content, ok := m.renderedProject.get(m.selectedProject, m.width); ok {
+		return content
+	}
+
+	project := m.projects[m.selectedProject]
+	content := contentStyle.Render(renderMarkdownForDisplay(ProjectMarkdown(project), m.width))
+	m.renderedProject.set(m.selectedProject, m.width, content)
+	return content
+}
+
+// openProject shows the detail view of the project at index
+func (m *Model) openProject(index int) {
+	if index < 0 || index >= len(m.projects) {
+		return
+	}
+	m.showPage(ProjectsPageID)
+	m.selectedProject = index
+	m.viewingProject = true
+	m.logger.Info("project open", "name", m.projects[index].Name)
+	m.updateViewportContent()
+}
+
+// projectsPage is the Projects section: the project cards, the filters
+// and the detail view
+type projectsPage struct{}
+
+func (projectsPage) Title() string {
+	return "Projects"
+}
+
+func (projectsPage) View(m *Model) string {
+	switch {
+	case m.viewingProject:
+		return m.getProjectDetailContent()
+	case m.viewingFilters:
+		return m.getProjectFiltersContent()
+	default:
+		return m.getProjectsContent()
+	}
+}
+
+func (projectsPage) Update(m *Model, k string) (bool, tea.Cmd) {
+	switch {
+	case m.viewingProject:
+		if KeyMatches(k, m.keys.Back) {
+			m.viewingProject = false
+			m.updateViewportContent()
+			return true, nil
+		}
+		return m.pageNavKey(k), nil
+	case m.viewingFilters:
+		return m.updateProjectFilters(k) || m.pageNavKey(k), nil
+	}
+
+	switch {
+	case KeyMatches(k, m.keys.Up):
+		m.moveProjectSelection(-1)
+
+	case KeyMatches(k, m.keys.Down):
+		m.moveProjectSelection(1)
+
+	case KeyMatches(k, m.keys.Top):
+		m.moveProjectSelection(-len(m.projects))
+
+	case KeyMatches(k, m.keys.Bottom):
+		m.moveProjectSelection(len(m.projects))
+		m.updateViewportContent()
+		m.viewport.GotoBottom()
+		return true, nil
+
+	case KeyMatches(k, m.keys.Filter):
+		m.viewingFilters = true
+		m.selectedChip = 0
+
+	case KeyMatches(k, m.keys.Sort):
+		m.cycleProjectSort()
+
+	case KeyMatches(k, m.keys.ClearFilter):
+		if m.projectFilterChips() == "" {
+			return true, nil
+		}
+		m.clearProjectFilters()
+		m.projectSort = sortByOrder
+
+	case KeyMatches(k, m.keys.Select):
+		if len(m.visibleProjects()) > 0 {
+			m.openProject(m.selectedProject)
+		}
+		return true, nil
+
+	default:
+		return false, nil
+	}
+
+	m.updateViewportContent()
+	return true, nil
+}
+
+func (projectsPage) Help(m *Model) (string, []key.Binding) {
+	keys := m.keys
+	choose := pairBinding(keys.Up, keys.Down, "choose")
+	changePage := pairBinding(keys.PrevPage, keys.NextPage, "change page")
+
+	switch {
+	case m.viewingProject:
+		return "🚀 Project details", []key.Binding{pairBinding(keys.Up, keys.Down, "scroll"), pairBinding(keys.PageUp, keys.PageDown, "page"), withDesc(keys.Back, "return"), keys.Quit}
+	case m.viewingFilters:
+		return "🔎 Filter projects", []key.Binding{choose, keys.Toggle, withDesc(without(keys.ClearFilter, keys.Back, keys.Cancel, keys.Filter), "clear"), withDesc(keys.Back, "return"), keys.Quit}
+	}
+	if chips := m.projectFilterChips(); chips != "" {
+		return "🛠️  Projects " + chips, []key.Binding{withDesc(keys.ClearFilter, "clear"), choose, withDesc(keys.Select, "details"), keys.Filter, keys.Sort, changePage}
+	}
+	return "🛠️  Projects", []key.Binding{choose, withDesc(keys.Select, "details"), keys.Filter, keys.Sort, keys.Search, changePage, keys.Quit}
+}
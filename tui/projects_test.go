@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"io"
+	"testing"
+
+	"github.com/charmbracelet/log"
+)
+
+func TestProjectDetailIsCached(t *testing.T) {
+	m := NewModel(100, 30, log.New(io.Discard))
+	m.projects = []Project{
+		{Name: "First", Description: "The first project.", Status: "Active"},
+		{Name: "Second", Description: "The second project.", Status: "Archived"},
+	}
+	m.selectedProject = 1
+
+	first := m.getProjectDetailContent()
+	if cached, ok := m.renderedProject.get(1, m.width); !ok || cached != first {
+		t.Fatal("project detail was not cached")
+	}
+
+	// A stale entry is served as is, which shows the cache is used
+	m.renderedProject.set(1, m.width, "cached")
+	if got := m.getProjectDetailContent(); got != "cached" {
+		t.Errorf("detail = %q, want the cached render", got)
+	}
+
+	// Another project or width renders again
+	m.selectedProject = 0
+	if got := m.getProjectDetailContent(); got == "cached" {
+		t.Error("another project was served from the cache")
+	}
+	m.selectedProject = 1
+	m.width = 60
+	if got := m.getProjectDetailContent(); got == "cached" {
+		t.Error("another width was served from the cache")
+	}
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// anonymousVisitor is the ID used in the session-only store given to
+// visitors without a public key
+const anonymousVisitor = "anonymous"
+
+// readThreshold is how far through a post counts as having read it
+const readThreshold = 0.98
+
+// shortPostReadTime is how long a post that fits on one screen must stay
+// open to count as read, since it starts out scrolled to the end
+const shortPostReadTime = 10 * time.Second
+
+var noticeStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.Color("#F25D94")).
+	Bold(true)
+
+// postIndex returns the position of the post with the given ID in the
+// blog list, or -1 if it is not published
+func (m Model) postIndex(id string) int {
+	for i, entry := range m.blogEntries {
+		if entry.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// savedPosts returns the indexes of bookmarked posts that are still
+// published, in the order they were saved
+func (m Model) savedPosts() []int {
+	var saved []int
+	for _, b := range m.visitors.Bookmarks(m.visitorID) {
+		if i := m.postIndex(b.Post); i >= 0 {
+			saved = append(saved, i)
+		}
+	}
+	return saved
+}
+
+// toggleBookmark adds the post at index to the reading list or removes it
+func (m *Model) toggleBookmark(index int) {
+	if index < 0 || index >= len(m.blogEntries) {
+		return
+	}
+
+	entry := m.blogEntries[index]
+	if m.visitors.ToggleBookmark(m.visitorID, entry.ID) {
+		m.notice = "🔖 Saved to your reading list"
+		m.logger.Info("bookmark", "id", entry.ID)
+	} else {
+		m.notice = "Removed from your reading list"
+		m.logger.Info("unbookmark", "id", entry.ID)
+	}
+	if m.visitorID == anonymousVisitor {
+		m.notice += " for this session"
+	}
+}
+
+// postBadges describes whether a post is saved and whether it has been
+// read, for cards and the reading list
+func (m Model) postBadges(index int) string {
+	id := m.blogEntries[index].ID
+
+	var badges []string
+	if m.visitors.Bookmarked(m.visitorID, id) {
+		badges = append(badges, "🔖 Saved")
+	}
+	if m.visitors.IsRead(m.visitorID, id) {
+		badges = append(badges, "✅ Read")
+	} else if position, ok := m.visitors.Position(m.visitorID, id); ok && position > 0 {
+		badges = append(badges, fmt.Sprintf("📖 %.0f%% read", position*100))
+	}
+	return strings.Join(badges, "  ")
+}
+
+// updateReadingList handles keys in the Reading list view, reporting
+// whether the key was used
+func (m *Model) updateReadingList(k string) bool {
+	saved := m.savedPosts()
+
+	switch {
+	case KeyMatches(k, m.keys.Up):
+		if m.selectedSaved > 0 {
+			m.selectedSaved--
+		}
+
+	case KeyMatches(k, m.keys.Down):
+		if m.selectedSaved < len(saved)-1 {
+			m.selectedSaved++
+		}
+
+	case KeyMatches(k, m.keys.Select):
+		if m.selectedSaved < len(saved) {
+			m.selectedBlogEntry = saved[m.selectedSaved]
+			m.viewingBlogEntry = true
+			m.logPostOpen()
+			m.updateViewportContent()
+			m.restorePosition()
+		}
+		return true
+
+	case KeyMatches(k, m.keys.Bookmark):
+		if m.selectedSaved < len(saved) {
+			m.toggleBookmark(saved[m.selectedSaved])
+			m.selectedSaved = max(0, min(m.selectedSaved, len(saved)-2))
+		}
+
+	case KeyMatches(k, m.keys.Back, m.keys.Cancel, m.keys.ReadingList):
+		m.viewingSaved = false
+
+	default:
+		return false
+	}
+
+	m.updateViewportContent()
+	return true
+}
+
+// getReadingListContent lists the visitor's saved posts and whether each
+// has been read
+func (m Model) getReadingListContent() string {
+	header := "🔖 Reading list\n\n"
+	if m.visitorID == anonymousVisitor {
+		header += "Connect with an SSH key to keep your list between visits; without one it lasts for this session.\n"
+	} else {
+		header += fmt.Sprintf("Saved to your SSH key. Use %s to choose a post, %s to read, %s to remove.\n",
+			keyHint(pairBinding(m.keys.Up, m.keys.Down, "")), keyHint(m.keys.Select), keyHint(m.keys.Bookmark))
+	}
+
+	saved := m.savedPosts()
+	if len(saved) == 0 {
+		return contentStyle.Render(header + fmt.Sprintf("\nNothing saved yet. Press %s on a post to add it here.", keyHint(m.keys.Bookmark)))
+	}
+
+	var cards []string
+	for i, index := range saved {
+		entry := m.blogEntries[index]
+		card := fmt.Sprintf("📝 %s\n\n%s\n\n%s", entry.Title, entry.Byline(), m.postBadges(index))
+
+		if i == m.selectedSaved {
+			cards = append(cards, selectedCardStyle.Render(card))
+		} else {
+			cards = append(cards, cardStyle.Render(card))
+		}
+	}
+
+	return contentStyle.Render(header) + "\n" + strings.Join(cards, "\n")
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Reading speeds used to estimate read time. Code is read more slowly than
+// prose, so it is counted separately.
+const (
+	proseWordsPerMinute = 200
+	codeWordsPerMinute  = 100
+)
+
+// CountWords returns the number of prose words and the number of words
+// inside fenced code blocks in a markdown document. Markup such as heading
+// markers and list bullets is not counted.
+func CountWords(markdown string) (prose, code int) {
+	var fence string
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			fence = trimmed[:3]
+			continue
+		}
+		if fence != "" && strings.HasPrefix(trimmed, fence) {
+			fence = ""
+			continue
+		}
+
+		for _, field := range strings.Fields(line) {
+			if !strings.ContainsFunc(field, isWordRune) {
+				continue
+			}
+			if fence != "" {
+				code++
+			} else {
+				prose++
+			}
+		}
+	}
+	return prose, code
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// EstimateReadTime formats the time needed to read prose and code words,
+// rounded up to whole minutes
+func EstimateReadTime(prose, code int) string {
+	minutes := float64(prose)/proseWordsPerMinute + float64(code)/codeWordsPerMinute
+	return fmt.Sprintf("%d min read", max(1, int(math.Ceil(minutes))))
+}
+
+// withReadingStats fills in the word count, and the read time unless the
+// post already sets one
+func withReadingStats(post BlogPost) BlogPost {
+	prose, code := CountWords(post.Content)
+	post.WordCount = prose + code
+	if post.ReadTime == "" {
+		post.ReadTime = EstimateReadTime(prose, code)
+	}
+	return post
+}
+
+// formatCount renders n with thousands separators, e.g. 1,234
+func formatCount(n int) string {
+	s := fmt.Sprint(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name      string
+		markdown  string
+		wantProse int
+		wantCode  int
+	}{
+		{name: "empty", markdown: "", wantProse: 0, wantCode: 0},
+		{name: "plain prose", markdown: "one two three", wantProse: 3},
+		{name: "markup is not counted", markdown: "# Title\n\n- item one\n* item two\n\n---\n> quote", wantProse: 6},
+		{name: "backtick fence", markdown: "before\n```go\nfunc main() {}\n```\nafter", wantProse: 2, wantCode: 2},
+		{name: "tilde fence", markdown: "~~~\na b c\n~~~", wantCode: 3},
+		{name: "backticks inside a tilde fence", markdown: "~~~\n```\ncode\n~~~\nprose", wantProse: 1, wantCode: 1},
+		{name: "unterminated fence counts as code", markdown: "intro\n```\nx := 1", wantProse: 1, wantCode: 2},
+		{name: "non-ASCII words", markdown: "café naïve 日本語 — 42", wantProse: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prose, code := CountWords(tt.markdown)
+			if prose != tt.wantProse || code != tt.wantCode {
+				t.Errorf("CountWords(%q) = %d, %d, want %d, %d", tt.markdown, prose, code, tt.wantProse, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestEstimateReadTime(t *testing.T) {
+	tests := []struct {
+		prose, code int
+		want        string
+	}{
+		{prose: 0, code: 0, want: "1 min read"},
+		{prose: 200, code: 0, want: "1 min read"},
+		{prose: 201, code: 0, want: "2 min read"},
+		{prose: 0, code: 100, want: "1 min read"},
+		{prose: 0, code: 200, want: "2 min read"},
+		// 200 prose words and 200 code words take 1 + 2 minutes
+		{prose: 200, code: 200, want: "3 min read"},
+		{prose: 1000, code: 50, want: "6 min read"},
+	}
+
+	for _, tt := range tests {
+		if got := EstimateReadTime(tt.prose, tt.code); got != tt.want {
+			t.Errorf("EstimateReadTime(%d, %d) = %q, want %q", tt.prose, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestWithReadingStats(t *testing.T) {
+	body := strings.Repeat("word ", 450) + "\n```\n" + strings.Repeat("x ", 100) + "\n```\n"
+
+	tests := []struct {
+		name     string
+		readTime string
+		want     string
+	}{
+		{name: "estimated", readTime: "", want: "4 min read"},
+		{name: "frontmatter wins", readTime: "8 min read", want: "8 min read"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post := withReadingStats(BlogPost{Content: body, ReadTime: tt.readTime})
+			if post.WordCount != 550 {
+				t.Errorf("WordCount = %d, want 550", post.WordCount)
+			}
+			if post.ReadTime != tt.want {
+				t.Errorf("ReadTime = %q, want %q", post.ReadTime, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,458 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SearchKind identifies what a search result points at
+type SearchKind int
+
+const (
+	SearchPost SearchKind = iota
+	SearchProject
+)
+
+// Field weights; a hit in a title counts for more than one in a body
+const (
+	weightTitle   = 5.0
+	weightTags    = 3.0
+	weightSummary = 2.0
+	weightBody    = 1.0
+)
+
+// maxSearchResults caps the number of results shown on the search page
+const maxSearchResults = 20
+
+// SearchResult is a single ranked match
+type SearchResult struct {
+	Kind    SearchKind
+	Index   int // position in the model's blog entries or projects
+	Title   string
+	Snippet string
+	Score   float64
+}
+
+type searchDoc struct {
+	kind  SearchKind
+	index int
+	title string
+	text  string // summary or description, used for snippets
+	body  string
+}
+
+// SearchIndex is an inverted index over posts and projects
+type SearchIndex struct {
+	docs     []searchDoc
+	postings map[string]map[int]float64 // term -> doc -> weighted frequency
+	terms    []string                   // sorted, for prefix lookups
+}
+
+// NewSearchIndex indexes the titles, summaries, tags and bodies of posts
+// and the fields of projects
+func NewSearchIndex(posts []BlogPost, projects []Project) *SearchIndex {
+	idx := &SearchIndex{postings: make(map[string]map[int]float64)}
+
+	for i, post := range posts {
+		id := idx.addDoc(searchDoc{
+			kind:  SearchPost,
+			index: i,
+			title: post.Title,
+			text:  post.Summary,
+			body:  markdownSymbols.Replace(post.Content),
+		})
+		idx.addField(id, post.Title, weightTitle)
+		idx.addField(id, strings.Join(post.Tags, " "), weightTags)
+		idx.addField(id, post.Summary, weightSummary)
+		idx.addField(id, post.Content, weightBody)
+	}
+
+	for i, project := range projects {
+		id := idx.addDoc(searchDoc{
+			kind:  SearchProject,
+			index: i,
+			title: project.Name,
+			text:  project.Description,
+			body:  strings.Join(project.Features, " "),
+		})
+		idx.addField(id, project.Name, weightTitle)
+		idx.addField(id, strings.Join(project.Tech, " "), weightTags)
+		idx.addField(id, project.Description+" "+project.Status, weightSummary)
+		idx.addField(id, strings.Join(project.Features, " "), weightBody)
+	}
+
+	for term := range idx.postings {
+		idx.terms = append(idx.terms, term)
+	}
+	sort.Strings(idx.terms)
+
+	return idx
+}
+
+func (idx *SearchIndex) addDoc(doc searchDoc) int {
+	idx.docs = append(idx.docs, doc)
+	return len(idx.docs) - 1
+}
+
+func (idx *SearchIndex) addField(id int, text string, weight float64) {
+	for _, term := range tokenize(text) {
+		docs, ok := idx.postings[term]
+		if !ok {
+			docs = make(map[int]float64)
+			idx.postings[term] = docs
+		}
+		docs[id] += weight
+	}
+}
+
+// Search returns documents matching every term in query, best first. The
+// last term is matched as a prefix so results update while typing.
+func (idx *SearchIndex) Search(query string) []SearchResult {
+	terms := tokenize(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var scores map[int]float64
+	for i, term := range terms {
+		matched := idx.lookup(term, i == len(terms)-1)
+
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for id := range scores {
+			if s, ok := matched[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	results := make([]SearchResult, 0, len(scores))
+	for id, score := range scores {
+		doc := idx.docs[id]
+		results = append(results, SearchResult{
+			Kind:    doc.kind,
+			Index:   doc.index,
+			Title:   doc.title,
+			Snippet: snippet(doc, terms),
+			Score:   score,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Title < results[j].Title
+	})
+
+	if len(results) > maxSearchResults {
+		results = results[:maxSearchResults]
+	}
+	return results
+}
+
+// lookup scores the documents containing term, weighting rare terms higher
+func (idx *SearchIndex) lookup(term string, prefix bool) map[int]float64 {
+	scores := make(map[int]float64)
+
+	add := func(t string) {
+		docs := idx.postings[t]
+		idf := math.Log(1 + float64(len(idx.docs))/float64(len(docs)))
+		for id, tf := range docs {
+			scores[id] += tf * idf
+		}
+	}
+
+	if !prefix {
+		add(term)
+		return scores
+	}
+
+	start := sort.SearchStrings(idx.terms, term)
+	for _, t := range idx.terms[start:] {
+		if !strings.HasPrefix(t, term) {
+			break
+		}
+		add(t)
+	}
+	return scores
+}
+
+// tokenize lowercases text and splits it into letter and digit runs
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// snippet picks the summary when it mentions a term, otherwise the body
+// text around the first hit
+func snippet(doc searchDoc, terms []string) string {
+	const width = 100
+
+	if doc.text != "" && containsAny(doc.text, terms) >= 0 {
+		return truncate(doc.text, width)
+	}
+
+	body := strings.Join(strings.Fields(doc.body), " ")
+	pos := containsAny(body, terms)
+	if pos < 0 {
+		return truncate(doc.text, width)
+	}
+
+	start := pos - width/3
+	prefix := "…"
+	if start <= 0 {
+		start, prefix = 0, ""
+	} else if space := strings.IndexByte(body[start:pos], ' '); space >= 0 {
+		start += space + 1
+	} else {
+		for start < pos && !utf8.RuneStart(body[start]) {
+			start++
+		}
+	}
+	return prefix + truncate(body[start:], width)
+}
+
+// markdownSymbols strips the markup that would otherwise show up in snippets
+var markdownSymbols = strings.NewReplacer("#", "", "*", "", "`", "", ">", "")
+
+// containsAny returns the byte offset in text of the first term found,
+// ignoring case
+func containsAny(text string, terms []string) int {
+	lower, offsets := lowerOffsets(text)
+	first := -1
+	for _, term := range terms {
+		if i := strings.Index(lower, term); i >= 0 && (first < 0 || offsets[i] < first) {
+			first = offsets[i]
+		}
+	}
+	return first
+}
+
+// lowerOffsets lowercases text and maps each byte of the result back to
+// the offset of the rune it came from, since some runes change length
+// when lowercased
+func lowerOffsets(text string) (string, []int) {
+	var b strings.Builder
+	offsets := make([]int, 0, len(text))
+	for i, r := range text {
+		n, _ := b.WriteRune(unicode.ToLower(r))
+		for ; n > 0; n-- {
+			offsets = append(offsets, i)
+		}
+	}
+	return b.String(), offsets
+}
+
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return strings.TrimSpace(string(runes[:width])) + "…"
+}
+
+var (
+	searchHighlightStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color("#1a1a1a")).
+				Background(lipgloss.Color("#F25D94"))
+
+	searchPromptStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#7D56F4")).
+				Bold(true)
+)
+
+// highlight wraps every case-insensitive occurrence of the query terms in
+// text with style
+func highlight(text string, terms []string, style lipgloss.Style) string {
+	lower, offsets := lowerOffsets(text)
+
+	marked := make([]bool, len(text))
+	for _, term := range terms {
+		for from := 0; ; {
+			i := strings.Index(lower[from:], term)
+			if i < 0 {
+				break
+			}
+			start, end := offsets[from+i], len(text)
+			if from+i+len(term) < len(lower) {
+				end = offsets[from+i+len(term)]
+			}
+			for j := start; j < end; j++ {
+				marked[j] = true
+			}
+			from += i + len(term)
+		}
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); {
+		j := i
+		for j < len(text) && marked[j] == marked[i] {
+			j++
+		}
+		if marked[i] {
+			b.WriteString(style.Render(text[i:j]))
+		} else {
+			b.WriteString(text[i:j])
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// getSearchContent renders the search input and the ranked results
+func (m Model) getSearchContent() string {
+	var b strings.Builder
+	b.WriteString("🔍 Search posts and projects\n\n")
+	b.WriteString(m.searchInput.View())
+	b.WriteString("\n")
+
+	query := strings.TrimSpace(m.searchInput.Value())
+	if query == "" {
+		b.WriteString("\nType to search titles, summaries, tags, post bodies and projects.\n")
+		return contentStyle.Render(b.String())
+	}
+
+	if len(m.searchResults) == 0 {
+		b.WriteString(fmt.Sprintf("\nNo results for %q\n", query))
+		return contentStyle.Render(b.String())
+	}
+
+	terms := tokenize(query)
+	var cards []string
+	for i, result := range m.searchResults {
+		icon := "📝"
+		if result.Kind == SearchProject {
+			icon = "🚀"
+		}
+		card := fmt.Sprintf("%s %s\n\n%s",
+			icon,
+			highlight(result.Title, terms, searchHighlightStyle),
+			highlight(result.Snippet, terms, searchHighlightStyle))
+
+		if i == m.selectedResult {
+			cards = append(cards, selectedCardStyle.Render(card))
+		} else {
+			cards = append(cards, cardStyle.Render(card))
+		}
+	}
+
+	return contentStyle.Render(b.String()) + "\n" + strings.Join(cards, "\n")
+}
+
+// openSearch switches to the search page and focuses the input
+func (m *Model) openSearch() tea.Cmd {
+	m.searching = true
+	m.searchInput.SetValue("")
+	m.searchResults = nil
+	m.selectedResult = 0
+	m.updateViewportContent()
+	return m.searchInput.Focus()
+}
+
+// closeSearch leaves the search page and restores the previous view
+func (m *Model) closeSearch() {
+	m.searching = false
+	m.searchInput.Blur()
+	m.updateViewportContent()
+}
+
+// updateSearch handles keys while the search page is open
+func (m Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Cancel):
+		m.closeSearch()
+		return m, nil
+
+	case key.Matches(msg, m.keys.PrevResult):
+		if m.selectedResult > 0 {
+			m.selectedResult--
+			m.refreshSearch()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.NextResult):
+		if m.selectedResult < len(m.searchResults)-1 {
+			m.selectedResult++
+			m.refreshSearch()
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keys.Select):
+		if len(m.searchResults) > 0 {
+			m.openResult(m.searchResults[m.selectedResult])
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	previous := m.searchInput.Value()
+	m.searchInput, cmd = m.searchInput.Update(msg)
+	if m.searchInput.Value() != previous {
+		m.searchResults = m.searchIndex.Search(m.searchInput.Value())
+		m.selectedResult = 0
+		m.refreshSearch()
+	}
+	return m, cmd
+}
+
+// refreshSearch redraws the results, keeping the selected card in view
+func (m *Model) refreshSearch() {
+	m.viewport.SetContent(m.getSearchContent())
+
+	// Each card is roughly six lines tall below a five line header
+	line := 5 + m.selectedResult*6
+	if line < m.viewport.YOffset || line+6 > m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(line)
+	}
+	if m.selectedResult == 0 {
+		m.viewport.GotoTop()
+	}
+}
+
+// openResult closes the search page and shows the chosen post or project
+func (m *Model) openResult(result SearchResult) {
+	m.searching = false
+	m.searchInput.Blur()
+	m.logger.Info("search", "query", m.searchInput.Value(), "result", result.Title)
+
+	switch result.Kind {
+	case SearchPost:
+		m.showPage(BlogPageID)
+		m.viewingTags = false
+		m.viewingSaved = false
+		if m.tagFilter != "" && !m.blogEntries[result.Index].hasTag(m.tagFilter) {
+			m.setTagFilter("")
+		}
+		m.selectedBlogEntry = result.Index
+		m.viewingBlogEntry = true
+		m.logPostOpen()
+		m.updateViewportContent()
+		m.restorePosition()
+
+	case SearchProject:
+		m.viewingBlogEntry = false
+		m.viewingTags = false
+		m.viewingSaved = false
+		m.viewingFilters = false
+		if !m.projectMatches(m.projects[result.Index]) {
+			m.clearProjectFilters()
+		}
+		m.openProject(result.Index)
+	}
+}
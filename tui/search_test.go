@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// searchFixture is a small corpus of posts and projects for search tests
+func searchFixture() *SearchIndex {
+	posts := []BlogPost{
+		{
+			Title:   "Building Terminal UIs",
+			Summary: "Making apps with Bubble Tea.",
+			Tags:    []string{"go", "tui"},
+			Content: "Bubble Tea follows the Elm architecture. Models update on messages.",
+		},
+		{
+			Title:   "Go vs Rust",
+			Summary: "Two systems languages compared.",
+			Tags:    []string{"go", "rust"},
+			Content: "Rust has a borrow checker. Go has a garbage collector and a terminal friendly toolchain.",
+		},
+		{
+			Title:   "Café notes",
+			Summary: "Writing in cafés.",
+			Tags:    []string{"life"},
+			Content: "Naïve résumé of a day spent at the ÉCOLE café.",
+		},
+		{
+			Title:   "Unicode edge cases",
+			Summary: "Where byte lengths change.",
+			Content: strings.Repeat("Ⱥ", 60) + " needle",
+		},
+		{
+			Title:   "Long read",
+			Summary: "A post that mentions its topic at the very end.",
+			Content: strings.Repeat("filler words here ", 40) + "finally the epilogue",
+		},
+	}
+	projects := []Project{
+		{
+			Name:        "Terminal Portfolio",
+			Description: "A portfolio served over SSH.",
+			Tech:        []string{"Go", "Bubble Tea"},
+			Features:    []string{"Blog reader", "Full-text search"},
+			Status:      "Active",
+		},
+	}
+	return NewSearchIndex(posts, projects)
+}
+
+func TestSearch(t *testing.T) {
+	idx := searchFixture()
+
+	tests := []struct {
+		name  string
+		query string
+		// want lists the titles expected, in order
+		want []string
+	}{
+		{name: "empty query", query: "  ", want: nil},
+		{name: "no match", query: "haskell", want: nil},
+		{name: "titles beat bodies, ties go by title", query: "terminal", want: []string{"Building Terminal UIs", "Terminal Portfolio", "Go vs Rust"}},
+		{name: "tags count", query: "rust", want: []string{"Go vs Rust"}},
+		{name: "every term must match", query: "bubble architecture", want: []string{"Building Terminal UIs"}},
+		{name: "terms in any order", query: "architecture bubble", want: []string{"Building Terminal UIs"}},
+		{name: "only the last term is a prefix", query: "borrow chec", want: []string{"Go vs Rust"}},
+		{name: "earlier terms must be whole", query: "borr checker", want: nil},
+		{name: "case is ignored", query: "BUBBLE tea", want: []string{"Building Terminal UIs", "Terminal Portfolio"}},
+		{name: "punctuation splits terms", query: "full-text", want: []string{"Terminal Portfolio"}},
+		{name: "accented terms", query: "résumé", want: []string{"Café notes"}},
+		{name: "accented prefix with different case", query: "éco", want: []string{"Café notes"}},
+		{name: "runes that grow when lowercased", query: "needle", want: []string{"Unicode edge cases"}},
+		{name: "match at the end of the body", query: "epilogue", want: []string{"Long read"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, result := range idx.Search(tt.query) {
+				got = append(got, result.Title)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("Search(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchResultsAreCapped(t *testing.T) {
+	var posts []BlogPost
+	for i := 0; i < maxSearchResults+5; i++ {
+		posts = append(posts, BlogPost{Title: fmt.Sprintf("Post %02d", i), Content: "common"})
+	}
+	results := NewSearchIndex(posts, nil).Search("common")
+	if len(results) != maxSearchResults {
+		t.Fatalf("%d results, want %d", len(results), maxSearchResults)
+	}
+	// Equal scores fall back to title order
+	if results[0].Title != "Post 00" || results[len(results)-1].Title != "Post 19" {
+		t.Errorf("results run from %q to %q", results[0].Title, results[len(results)-1].Title)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   searchDoc
+		terms []string
+		want  string
+	}{
+		{
+			name:  "summary that mentions a term",
+			doc:   searchDoc{text: "All about Bubble Tea.", body: "bubble everywhere"},
+			terms: []string{"bubble"},
+			want:  "All about Bubble Tea.",
+		},
+		{
+			name:  "body near the start",
+			doc:   searchDoc{text: "Summary.", body: "The needle is early."},
+			terms: []string{"needle"},
+			want:  "The needle is early.",
+		},
+		{
+			name:  "body near the end starts at a word",
+			doc:   searchDoc{text: "Summary.", body: strings.Repeat("word ", 30) + "needle"},
+			terms: []string{"needle"},
+			want:  "…word word word word word word needle",
+		},
+		{
+			name:  "whitespace is collapsed",
+			doc:   searchDoc{text: "Summary.", body: "one\n\n  two\tneedle"},
+			terms: []string{"needle"},
+			want:  "one two needle",
+		},
+		{
+			name:  "runes that grow when lowercased",
+			doc:   searchDoc{text: "Summary.", body: strings.Repeat("Ⱥ", 60) + " needle"},
+			terms: []string{"needle"},
+			want:  "…needle",
+		},
+		{
+			name:  "long run without spaces is cut on a rune boundary",
+			doc:   searchDoc{text: "Summary.", body: strings.Repeat("é", 60) + "needle"},
+			terms: []string{"needle"},
+			want:  "…" + strings.Repeat("é", 16) + "needle",
+		},
+		{
+			name:  "first of several terms",
+			doc:   searchDoc{text: "Summary.", body: "alpha beta gamma"},
+			terms: []string{"gamma", "beta"},
+			want:  "alpha beta gamma",
+		},
+		{
+			name:  "no hit falls back to the summary",
+			doc:   searchDoc{text: "Summary.", body: "nothing here"},
+			terms: []string{"needle"},
+			want:  "Summary.",
+		},
+		{
+			name:  "long text is truncated",
+			doc:   searchDoc{text: strings.Repeat("needle ", 20)},
+			terms: []string{"needle"},
+			want:  strings.TrimSpace(strings.Repeat("needle ", 15)[:100]) + "…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := snippet(tt.doc, tt.terms); got != tt.want {
+				t.Errorf("snippet = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsAny(t *testing.T) {
+	tests := []struct {
+		text  string
+		terms []string
+		want  int
+	}{
+		{text: "hello world", terms: []string{"world"}, want: 6},
+		{text: "Hello World", terms: []string{"world", "hello"}, want: 0},
+		{text: "nothing", terms: []string{"else"}, want: -1},
+		{text: "ȺȺ needle", terms: []string{"needle"}, want: 5},
+		{text: "ȺȺ NEEDLE", terms: []string{"ⱥ"}, want: 0},
+		{text: "ÉCOLE café", terms: []string{"café"}, want: 7},
+		{text: "İstanbul", terms: []string{"stanbul"}, want: 2},
+	}
+
+	for _, tt := range tests {
+		if got := containsAny(tt.text, tt.terms); got != tt.want {
+			t.Errorf("containsAny(%q, %q) = %d, want %d", tt.text, tt.terms, got, tt.want)
+		}
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	style := searchHighlightStyle.UnsetBold().UnsetForeground().UnsetBackground().
+		SetString("").Inline(true).Transform(func(s string) string { return "[" + s + "]" })
+
+	tests := []struct {
+		text  string
+		terms []string
+		want  string
+	}{
+		{text: "Bubble Tea", terms: []string{"bubble"}, want: "[Bubble] Tea"},
+		{text: "a b a", terms: []string{"a"}, want: "[a] b [a]"},
+		{text: "terminal term", terms: []string{"term"}, want: "[term]inal [term]"},
+		{text: "Ⱥ needle", terms: []string{"needle"}, want: "Ⱥ [needle]"},
+		{text: "ȺȺ needle", terms: []string{"ⱥ"}, want: "[ȺȺ] needle"},
+		{text: "ÉCOLE café", terms: []string{"école", "café"}, want: "[ÉCOLE] [café]"},
+	}
+
+	for _, tt := range tests {
+		if got := highlight(tt.text, tt.terms, style); got != tt.want {
+			t.Errorf("highlight(%q, %q) = %q, want %q", tt.text, tt.terms, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"io"
+	"time"
+
+	"github.com/Arpan-206/terminal-portfolio/recorder"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/log"
+)
+
+// Stream is a visitor connected over a transport other than SSH, such as
+// telnet or a browser WebSocket, that provides raw terminal input and
+// output plus out-of-band window size reports
+type Stream struct {
+	Protocol string
+	Remote   string
+	Term     string
+	Width    int
+	Height   int
+
+	Input  io.Reader
+	Output io.Writer
+
+	// Sizes delivers window size changes reported by the client
+	Sizes <-chan tea.WindowSizeMsg
+
+	// Done is closed when the client disconnects
+	Done <-chan struct{}
+}
+
+// RunStream runs a Model over a Stream until the visitor quits or
+// disconnects. Sessions are logged and recorded the same way as SSH ones.
+func RunStream(st Stream, rec *recorder.Recorder) {
+	id := NewSessionID()
+	logger := log.Default().With("session", id)
+	start := time.Now()
+
+	logger.Info("connect",
+		"protocol", st.Protocol,
+		"remote", st.Remote,
+		"term", st.Term,
+		"width", st.Width,
+		"height", st.Height,
+	)
+
+	out := st.Output
+	recording, err := rec.Start(id, st.Width, st.Height, st.Term)
+	if err != nil {
+		logger.Error("Could not start recording", "error", err)
+	}
+	if recording != nil {
+		logger.Info("recording session", "dir", rec.Dir())
+		defer recording.Close()
+		out = io.MultiWriter(st.Output, recording)
+	}
+
+	m := NewModel(st.Width, st.Height, logger)
+	program := tea.NewProgram(m,
+		tea.WithInput(st.Input),
+		tea.WithOutput(out),
+		tea.WithAltScreen(),
+	)
+
+	go func() {
+		// Bubble Tea cannot query the size of a stream, so the reported
+		// sizes are delivered as messages
+		program.Send(tea.WindowSizeMsg{Width: st.Width, Height: st.Height})
+		for {
+			select {
+			case size := <-st.Sizes:
+				if recording != nil {
+					recording.Resize(size.Width, size.Height)
+				}
+				program.Send(size)
+			case <-st.Done:
+				program.Quit()
+				return
+			}
+		}
+	}()
+
+	if _, err := program.Run(); err != nil {
+		logger.Error("app exit with error", "error", err)
+	}
+	program.Kill()
+
+	logger.Info("disconnect",
+		"remote", st.Remote,
+		"duration", time.Since(start).String(),
+	)
+}
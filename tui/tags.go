@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TagCount is a tag and the number of posts carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+var (
+	tagStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7D56F4"))
+
+	tagChipStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FAFAFA")).
+			Background(lipgloss.Color("#7D56F4")).
+			PaddingLeft(1).
+			PaddingRight(1)
+)
+
+// CountTags returns every tag used by entries with its post count, most
+// used first
+func CountTags(entries []BlogPost) []TagCount {
+	counts := make(map[string]int)
+	for _, entry := range entries {
+		for _, tag := range entry.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags
+}
+
+// hasTag reports whether entry is tagged with tag
+func (entry BlogPost) hasTag(tag string) bool {
+	for _, t := range entry.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// formatTags renders tags as "#tag" labels for cards and headers
+func formatTags(tags []string) string {
+	labels := make([]string, len(tags))
+	for i, tag := range tags {
+		labels[i] = "#" + tag
+	}
+	return tagStyle.Render(strings.Join(labels, " "))
+}
+
+// visibleBlogEntries returns the indexes of the entries that pass the tag
+// filter
+func (m Model) visibleBlogEntries() []int {
+	var visible []int
+	for i, entry := range m.blogEntries {
+		if m.tagFilter == "" || entry.hasTag(m.tagFilter) {
+			visible = append(visible, i)
+		}
+	}
+	return visible
+}
+
+// moveBlogSelection moves the selected card by delta among the visible
+// entries, clamping at either end
+func (m *Model) moveBlogSelection(delta int) {
+	visible := m.visibleBlogEntries()
+	if len(visible) == 0 {
+		return
+	}
+
+	pos := 0
+	for i, index := range visible {
+		if index == m.selectedBlogEntry {
+			pos = i
+		}
+	}
+	pos = max(0, min(len(visible)-1, pos+delta))
+	m.selectedBlogEntry = visible[pos]
+}
+
+// setTagFilter limits the blog list to posts tagged with tag, or shows all
+// posts again when tag is empty
+func (m *Model) setTagFilter(tag string) {
+	m.tagFilter = tag
+	if visible := m.visibleBlogEntries(); len(visible) > 0 {
+		m.selectedBlogEntry = visible[0]
+	}
+	if tag != "" {
+		m.logger.Info("tag filter", "tag", tag)
+	}
+}
+
+// updateTags handles keys in the Tags view, reporting whether the key was
+// used
+func (m *Model) updateTags(k string) bool {
+	tags := CountTags(m.blogEntries)
+
+	switch {
+	case KeyMatches(k, m.keys.Up):
+		if m.selectedTag > 0 {
+			m.selectedTag--
+			m.updateViewportContent()
+		}
+
+	case KeyMatches(k, m.keys.Down):
+		if m.selectedTag < len(tags)-1 {
+			m.selectedTag++
+			m.updateViewportContent()
+		}
+
+	case KeyMatches(k, m.keys.Select):
+		if m.selectedTag < len(tags) {
+			m.setTagFilter(tags[m.selectedTag].Tag)
+		}
+		m.viewingTags = false
+		m.updateViewportContent()
+
+	case KeyMatches(k, m.keys.Back, m.keys.Cancel, m.keys.Tags):
+		m.viewingTags = false
+		m.updateViewportContent()
+
+	default:
+		return false
+	}
+	return true
+}
+
+// getTagsContent lists every tag with the number of posts using it
+func (m Model) getTagsContent() string {
+	tags := CountTags(m.blogEntries)
+	if len(tags) == 0 {
+		return contentStyle.Render("🏷️  Tags\n\nNo posts are tagged yet.")
+	}
+
+	var lines []string
+	for i, tc := range tags {
+		posts := "posts"
+		if tc.Count == 1 {
+			posts = "post"
+		}
+		line := fmt.Sprintf("#%-20s %d %s", tc.Tag, tc.Count, posts)
+
+		if i == m.selectedTag {
+			lines = append(lines, activeNavStyle.Render("▸ "+line))
+		} else {
+			lines = append(lines, "   "+line)
+		}
+	}
+
+	header := fmt.Sprintf("🏷️  Tags\n\nUse %s to choose a tag, %s to filter the blog list\n\n",
+		keyHint(pairBinding(m.keys.Up, m.keys.Down, "")), keyHint(m.keys.Select))
+	return contentStyle.Render(header + strings.Join(lines, "\n"))
+}
+
+// tagFilterChip renders the active tag filter for the footer
+func (m Model) tagFilterChip() string {
+	return tagChipStyle.Render("#" + m.tagFilter + " ✕")
+}
@@ -0,0 +1,302 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	// tocWidth is the width of the table of contents side pane
+	tocWidth = 32
+
+	// tocMinWidth is the narrowest terminal that shows the table of
+	// contents beside the post; narrower ones get an overlay instead
+	tocMinWidth = 110
+)
+
+// Heading is a markdown heading in a post
+type Heading struct {
+	Level int
+	Text  string
+}
+
+var (
+	tocPaneStyle = lipgloss.NewStyle().
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(lipgloss.Color("#7D56F4")).
+			PaddingLeft(1).
+			Width(tocWidth - 1)
+
+	tocOverlayStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#7D56F4")).
+			Padding(1, 2).
+			Width(tocWidth + 8)
+
+	tocCurrentStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F25D94")).
+			Bold(true)
+)
+
+// ExtractHeadings returns the ATX headings of a markdown document in order,
+// skipping anything inside fenced code blocks
+func ExtractHeadings(markdown string) []Heading {
+	var headings []Heading
+	var fence string
+
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if fence == "" && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			fence = trimmed[:3]
+			continue
+		}
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level == 0 || level > 6 || len(line) == level || line[level] != ' ' {
+			continue
+		}
+
+		text := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line[level:]), "#"))
+		text = markdownSymbols.Replace(text)
+		if text != "" {
+			headings = append(headings, Heading{Level: level, Text: text})
+		}
+	}
+
+	return headings
+}
+
+// headingLines finds the line of each heading in rendered post content.
+// Headings are matched in order on their visible text, so a heading is
+// never placed above the one before it. Headings that cannot be found
+// get -1.
+func headingLines(content string, headings []Heading) []int {
+	lines := strings.Split(content, "\n")
+	found := make([]int, len(headings))
+
+	from := 0
+	for i, heading := range headings {
+		found[i] = -1
+
+		// Long headings may wrap, so match on their start
+		needle := []rune(heading.Text)
+		if len(needle) > 30 {
+			needle = needle[:30]
+		}
+
+		for n := from; n < len(lines); n++ {
+			if strings.Contains(string(parseANSILine(lines[n]).runes), string(needle)) {
+				found[i] = n
+				from = n + 1
+				break
+			}
+		}
+	}
+
+	return found
+}
+
+// tocSideBySide reports whether the table of contents fits beside the post
+func (m Model) tocSideBySide() bool {
+	return m.width >= tocMinWidth
+}
+
+// readerWidth is the width available to the post itself
+func (m Model) readerWidth() int {
+	if m.viewingBlogEntry && m.tocOpen && m.tocSideBySide() {
+		return m.width - tocWidth
+	}
+	return m.width
+}
+
+// openPostHeadings returns the headings of the open post and the lines
+// they were rendered on, as indexed when the post was last drawn
+func (m Model) openPostHeadings() ([]Heading, []int) {
+	return m.tocHeadings, m.tocLines
+}
+
+// indexHeadings records where the open post's headings landed in content
+func (m *Model) indexHeadings(content string) {
+	m.tocHeadings, m.tocLines = nil, nil
+	if !m.viewingBlogEntry || m.selectedBlogEntry >= len(m.blogEntries) {
+		return
+	}
+	m.tocHeadings = ExtractHeadings(m.blogEntries[m.selectedBlogEntry].Content)
+	m.tocLines = headingLines(content, m.tocHeadings)
+}
+
+// currentHeading returns the index of the section at the top of the
+// viewport, or -1 before the first heading
+func (m Model) currentHeading(lines []int) int {
+	current := -1
+	for i, line := range lines {
+		if line >= 0 && line <= m.viewport.YOffset {
+			current = i
+		}
+	}
+	return current
+}
+
+// toggleTOC opens the table of contents, focuses it when it is already
+// visible beside the post, and closes it when it has focus
+func (m *Model) toggleTOC() {
+	switch {
+	case !m.tocOpen:
+		headings, lines := m.openPostHeadings()
+		if len(headings) == 0 {
+			return
+		}
+		m.tocOpen = true
+		m.tocFocused = true
+		m.tocSelected = max(0, m.currentHeading(lines))
+	case !m.tocFocused:
+		m.tocFocused = true
+	default:
+		m.tocOpen = false
+		m.tocFocused = false
+	}
+	m.layoutReader()
+}
+
+// closeTOC hides the table of contents
+func (m *Model) closeTOC() {
+	if !m.tocOpen {
+		return
+	}
+	m.tocOpen = false
+	m.tocFocused = false
+	m.layoutReader()
+}
+
+// layoutReader resizes the viewport around the side pane and re-renders the
+// post at the new width, staying at the same section
+func (m *Model) layoutReader() {
+	width := m.readerWidth()
+	if m.viewport.Width == width {
+		return
+	}
+
+	_, before := m.openPostHeadings()
+	section := m.currentHeading(before)
+
+	m.viewport.Width = width
+	if m.findInput.Value() != "" {
+		m.runFind()
+	} else {
+		m.refreshFind()
+	}
+
+	if _, after := m.openPostHeadings(); section >= 0 && section < len(after) && after[section] >= 0 {
+		m.viewport.SetYOffset(after[section])
+	}
+}
+
+// updateTOC handles keys while the table of contents has focus, reporting
+// whether the key was used
+func (m *Model) updateTOC(k string) bool {
+	headings, lines := m.openPostHeadings()
+
+	switch {
+	case KeyMatches(k, m.keys.Up):
+		if m.tocSelected > 0 {
+			m.tocSelected--
+		}
+
+	case KeyMatches(k, m.keys.Down):
+		if m.tocSelected < len(headings)-1 {
+			m.tocSelected++
+		}
+
+	case KeyMatches(k, m.keys.Select):
+		if m.tocSelected < len(lines) && lines[m.tocSelected] >= 0 {
+			m.viewport.SetYOffset(lines[m.tocSelected])
+		}
+		// The overlay covers the post, so get it out of the way
+		if m.tocSideBySide() {
+			m.tocFocused = false
+		} else {
+			m.closeTOC()
+		}
+
+	case KeyMatches(k, m.keys.Cancel):
+		if m.tocSideBySide() {
+			m.tocFocused = false
+		} else {
+			m.closeTOC()
+		}
+
+	case KeyMatches(k, m.keys.Contents):
+		m.closeTOC()
+
+	default:
+		return false
+	}
+	return true
+}
+
+// renderTOC renders the table of contents within width and height, marking
+// the section being read and, when focused, the selected heading. Long
+// lists scroll to keep that heading in view.
+func (m Model) renderTOC(width, height int) string {
+	headings, lines := m.openPostHeadings()
+	current := m.currentHeading(lines)
+
+	focus := current
+	if m.tocFocused {
+		focus = m.tocSelected
+	}
+
+	// Leave room for the title and the blank line under it
+	rows := max(1, height-2)
+	start := 0
+	if len(headings) > rows {
+		start = max(0, min(len(headings)-rows, focus-rows/2))
+	}
+	end := min(len(headings), start+rows)
+
+	items := []string{"📑 Contents", ""}
+	for i := start; i < end; i++ {
+		heading := headings[i]
+		marker := "  "
+		if m.tocFocused && i == m.tocSelected {
+			marker = "▸ "
+		}
+
+		indent := strings.Repeat(" ", 2*max(0, heading.Level-1))
+		item := ansi.Truncate(marker+indent+heading.Text, width, "…")
+
+		if i == current {
+			item = tocCurrentStyle.Render(item)
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, "\n")
+}
+
+// readerView renders the viewport with the table of contents beside it or,
+// while it has focus on a narrow terminal, over it
+func (m Model) readerView() string {
+	if !m.tocOpen || (!m.tocSideBySide() && !m.tocFocused) {
+		return m.viewport.View()
+	}
+
+	if m.tocSideBySide() {
+		toc := m.renderTOC(tocWidth-2, m.viewport.Height)
+		pane := tocPaneStyle.Height(m.viewport.Height).MaxHeight(m.viewport.Height).Render(toc)
+		return lipgloss.JoinHorizontal(lipgloss.Top, m.viewport.View(), pane)
+	}
+
+	// Inside the border and padding of the overlay box
+	toc := m.renderTOC(tocWidth+4, m.viewport.Height-4)
+	box := tocOverlayStyle.MaxHeight(m.viewport.Height).Render(toc)
+	return lipgloss.Place(m.width, m.viewport.Height, lipgloss.Center, lipgloss.Center, box)
+}
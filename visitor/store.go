@@ -0,0 +1,250 @@
+// Package visitor keeps per-visitor reading state, such as where each post
+// was left off and which posts are on their reading list, for visitors
+// identified by their SSH public key.
+package visitor
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Store holds the state of every known visitor in memory and persists it to
+// a JSON file. It is safe for concurrent use. A nil Store remembers
+// nothing.
+type Store struct {
+	path string
+
+	mu       sync.Mutex
+	visitors map[string]*record
+	dirty    bool
+}
+
+// record is what is stored for one visitor
+type record struct {
+	// Positions maps post IDs to how far through the post the visitor
+	// scrolled, from 0 to 1. A fraction survives the post being reflowed
+	// at a different terminal width.
+	Positions map[string]float64   `json:"positions,omitempty"`
+	Bookmarks []Bookmark           `json:"bookmarks,omitempty"`
+	Read      map[string]time.Time `json:"read,omitempty"`
+	LastSeen  time.Time            `json:"lastSeen"`
+}
+
+// Bookmark is a post saved to a visitor's reading list
+type Bookmark struct {
+	Post  string    `json:"post"`
+	Added time.Time `json:"added"`
+}
+
+// Open loads the store at path, starting empty if the file does not exist
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, visitors: make(map[string]*record)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.visitors); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewMemory returns a store that is never written to disk, for visitors
+// whose state should last only as long as their session
+func NewMemory() *Store {
+	return &Store{visitors: make(map[string]*record)}
+}
+
+// Path returns the file the store is saved to
+func (s *Store) Path() string {
+	return s.path
+}
+
+// visitor returns the record for id, creating it if needed. The caller
+// must hold s.mu.
+func (s *Store) visitor(id string) *record {
+	r, ok := s.visitors[id]
+	if !ok {
+		r = &record{}
+		s.visitors[id] = r
+	}
+	return r
+}
+
+// Position returns how far through post the visitor had scrolled
+func (s *Store) Position(id, post string) (float64, bool) {
+	if s == nil || id == "" {
+		return 0, false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.visitors[id]
+	if !ok {
+		return 0, false
+	}
+	pos, ok := r.Positions[post]
+	return pos, ok
+}
+
+// SetPosition records how far through post the visitor has scrolled. It
+// is kept in memory until the next Save.
+func (s *Store) SetPosition(id, post string, position float64) {
+	if s == nil || id == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.visitor(id)
+	if old, ok := r.Positions[post]; ok && old == position {
+		return
+	}
+	if r.Positions == nil {
+		r.Positions = make(map[string]float64)
+	}
+	r.Positions[post] = position
+	r.LastSeen = time.Now().UTC()
+	s.dirty = true
+}
+
+// Bookmarks returns the posts on the visitor's reading list, oldest first
+func (s *Store) Bookmarks(id string) []Bookmark {
+	if s == nil || id == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.visitors[id]; ok {
+		return append([]Bookmark(nil), r.Bookmarks...)
+	}
+	return nil
+}
+
+// Bookmarked reports whether post is on the visitor's reading list
+func (s *Store) Bookmarked(id, post string) bool {
+	for _, b := range s.Bookmarks(id) {
+		if b.Post == post {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleBookmark adds post to the visitor's reading list, or removes it if
+// it is already there. It reports whether the post is now bookmarked.
+func (s *Store) ToggleBookmark(id, post string) bool {
+	if s == nil || id == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.visitor(id)
+	r.LastSeen = time.Now().UTC()
+	s.dirty = true
+
+	for i, b := range r.Bookmarks {
+		if b.Post == post {
+			r.Bookmarks = append(r.Bookmarks[:i], r.Bookmarks[i+1:]...)
+			return false
+		}
+	}
+	r.Bookmarks = append(r.Bookmarks, Bookmark{Post: post, Added: r.LastSeen})
+	return true
+}
+
+// MarkRead records that the visitor has read post to the end
+func (s *Store) MarkRead(id, post string) {
+	if s == nil || id == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r := s.visitor(id)
+	if _, ok := r.Read[post]; ok {
+		return
+	}
+	if r.Read == nil {
+		r.Read = make(map[string]time.Time)
+	}
+	r.LastSeen = time.Now().UTC()
+	r.Read[post] = r.LastSeen
+	s.dirty = true
+}
+
+// IsRead reports whether the visitor has read post to the end
+func (s *Store) IsRead(id, post string) bool {
+	if s == nil || id == "" {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.visitors[id]
+	if !ok {
+		return false
+	}
+	_, read := r.Read[post]
+	return read
+}
+
+// Save writes the store to disk if anything changed since the last save.
+// Stores from NewMemory are never written.
+// The file is replaced atomically so a crash never leaves it half written.
+func (s *Store) Save() error {
+	if s == nil || s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.visitors, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".visitors-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	s.dirty = false
+	return nil
+}
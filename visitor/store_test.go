@@ -0,0 +1,201 @@
+package visitor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestStorePersists(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(s *Store)
+		check  func(t *testing.T, s *Store)
+	}{
+		{
+			name:   "position",
+			update: func(s *Store) { s.SetPosition("SHA256:abc", "post", 0.5) },
+			check: func(t *testing.T, s *Store) {
+				if pos, ok := s.Position("SHA256:abc", "post"); !ok || pos != 0.5 {
+					t.Errorf("Position = %v, %v, want 0.5, true", pos, ok)
+				}
+			},
+		},
+		{
+			name: "bookmarks keep their order",
+			update: func(s *Store) {
+				s.ToggleBookmark("SHA256:abc", "first")
+				s.ToggleBookmark("SHA256:abc", "second")
+				s.ToggleBookmark("SHA256:abc", "third")
+				s.ToggleBookmark("SHA256:abc", "second")
+			},
+			check: func(t *testing.T, s *Store) {
+				got := s.Bookmarks("SHA256:abc")
+				if len(got) != 2 || got[0].Post != "first" || got[1].Post != "third" {
+					t.Errorf("Bookmarks = %v, want first, third", got)
+				}
+			},
+		},
+		{
+			name:   "read posts",
+			update: func(s *Store) { s.MarkRead("SHA256:abc", "post") },
+			check: func(t *testing.T, s *Store) {
+				if !s.IsRead("SHA256:abc", "post") || s.IsRead("SHA256:abc", "other") {
+					t.Error("read state was not reloaded")
+				}
+			},
+		},
+		{
+			name:   "visitors are kept apart",
+			update: func(s *Store) { s.ToggleBookmark("SHA256:abc", "post") },
+			check: func(t *testing.T, s *Store) {
+				if s.Bookmarked("SHA256:xyz", "post") {
+					t.Error("another visitor's bookmark leaked")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state", "visitors.json")
+
+			s, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.update(s)
+			if err := s.Save(); err != nil {
+				t.Fatal(err)
+			}
+
+			reloaded, err := Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, reloaded)
+
+			entries, err := os.ReadDir(filepath.Dir(path))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) != 1 {
+				t.Errorf("state directory holds %d files, want only the store", len(entries))
+			}
+		})
+	}
+}
+
+func TestStoreSaveSkipsUnchanged(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "visitors.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Save wrote an unchanged store: %v", err)
+	}
+}
+
+func TestStoreConcurrentSessions(t *testing.T) {
+	const (
+		id       = "SHA256:shared"
+		sessions = 8
+		posts    = 25
+	)
+
+	path := filepath.Join(t.TempDir(), "visitors.json")
+	s, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Sessions with the same key save their own posts while saving the
+	// store, as the server does when each one ends
+	var wg sync.WaitGroup
+	for session := 0; session < sessions; session++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < posts; i++ {
+				post := fmt.Sprintf("post-%d-%d", session, i)
+				s.ToggleBookmark(id, post)
+				s.SetPosition(id, post, float64(i)/posts)
+				s.MarkRead(id, post)
+				s.Bookmarked(id, post)
+			}
+			if err := s.Save(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	reloaded, err := Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reloaded.Bookmarks(id)); got != sessions*posts {
+		t.Errorf("%d bookmarks after reload, want %d", got, sessions*posts)
+	}
+	for session := 0; session < sessions; session++ {
+		post := fmt.Sprintf("post-%d-%d", session, posts-1)
+		if !reloaded.IsRead(id, post) {
+			t.Errorf("%s is not read after reload", post)
+		}
+	}
+}
+
+func TestOpenCorruptStore(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "truncated", data: `{"SHA256:abc": {"positions": {"post": 0.5`},
+		{name: "wrong shape", data: `["SHA256:abc"]`},
+		{name: "not JSON", data: "visitors"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "visitors.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Open(path); err == nil {
+				t.Fatal("Open succeeded on a corrupt store")
+			}
+
+			// The file is left for the operator to inspect
+			data, err := os.ReadFile(path)
+			if err != nil || string(data) != tt.data {
+				t.Errorf("store file changed to %q, %v", data, err)
+			}
+		})
+	}
+}
+
+func TestNilAndMemoryStores(t *testing.T) {
+	var nilStore *Store
+	nilStore.SetPosition("SHA256:abc", "post", 1)
+	if nilStore.ToggleBookmark("SHA256:abc", "post") || nilStore.IsRead("SHA256:abc", "post") {
+		t.Error("nil store remembered something")
+	}
+	if err := nilStore.Save(); err != nil {
+		t.Errorf("nil Save = %v", err)
+	}
+
+	mem := NewMemory()
+	if !mem.ToggleBookmark("anonymous", "post") || !mem.Bookmarked("anonymous", "post") {
+		t.Error("memory store lost a bookmark")
+	}
+	if err := mem.Save(); err != nil || mem.Path() != "" {
+		t.Errorf("memory Save = %v, path %q", err, mem.Path())
+	}
+}
@@ -0,0 +1,34 @@
+// Bridges an xterm.js terminal to the portfolio over a WebSocket. Output
+// arrives as binary frames; keystrokes and resizes are sent as JSON.
+(function () {
+  const status = document.getElementById("status");
+  const term = new Terminal({ cursorBlink: false, fontFamily: "monospace" });
+  const fit = new FitAddon.FitAddon();
+  term.loadAddon(fit);
+  term.open(document.getElementById("terminal"));
+  fit.fit();
+
+  const scheme = location.protocol === "https:" ? "wss:" : "ws:";
+  const ws = new WebSocket(scheme + "//" + location.host + "/ws");
+  ws.binaryType = "arraybuffer";
+
+  const send = (msg) => {
+    if (ws.readyState === WebSocket.OPEN) {
+      ws.send(JSON.stringify(msg));
+    }
+  };
+  const sendSize = () => send({ type: "resize", cols: term.cols, rows: term.rows });
+
+  ws.onopen = () => {
+    sendSize();
+    term.focus();
+  };
+  ws.onmessage = (event) => term.write(new Uint8Array(event.data));
+  ws.onclose = () => {
+    status.textContent = "Disconnected. Reload to reconnect.";
+  };
+
+  term.onData((data) => send({ type: "input", data: data }));
+  term.onResize(sendSize);
+  window.addEventListener("resize", () => fit.fit());
+})();
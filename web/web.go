@@ -0,0 +1,147 @@
+// Package web serves the portfolio TUI to browsers through an embedded
+// xterm.js page connected to the server over a WebSocket.
+package web
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/Arpan-206/terminal-portfolio/recorder"
+	"github.com/Arpan-206/terminal-portfolio/tui"
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/net/websocket"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+const (
+	defaultWidth  = 80
+	defaultHeight = 24
+
+	// resizeTimeout is how long to wait for the browser to report its
+	// terminal size before starting with the defaults
+	resizeTimeout = time.Second
+)
+
+// vendorFiles are the xterm.js assets fetched by `make web-assets`; the
+// page cannot draw the terminal without them
+var vendorFiles = []string{"vendor/xterm.js", "vendor/xterm.css", "vendor/addon-fit.js"}
+
+// message is a control frame sent by the browser. Keystrokes arrive as
+// "input" messages and terminal size changes as "resize" messages.
+type message struct {
+	Type string `json:"type"`
+	Data string `json:"data,omitempty"`
+	Cols int    `json:"cols,omitempty"`
+	Rows int    `json:"rows,omitempty"`
+}
+
+// NewHandler returns the routes for the web terminal: the static page and
+// its assets at / and the terminal stream at /ws. It fails if the binary
+// was built without the xterm.js assets.
+func NewHandler(rec *recorder.Recorder) (http.Handler, error) {
+	static, _ := fs.Sub(staticFiles, "static")
+	for _, name := range vendorFiles {
+		if _, err := fs.Stat(static, name); err != nil {
+			return nil, fmt.Errorf("%s is not embedded, run `make web-assets` before building: %w", name, err)
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(static))
+	mux.Handle("GET /ws", websocket.Server{
+		Handshake: checkSameOrigin,
+		Handler: func(ws *websocket.Conn) {
+			serveTerminal(ws, rec)
+		},
+	})
+	return mux, nil
+}
+
+// checkSameOrigin rejects WebSocket connections opened by pages served
+// from another host
+func checkSameOrigin(config *websocket.Config, r *http.Request) error {
+	origin, err := url.Parse(r.Header.Get("Origin"))
+	if err != nil || origin.Host != r.Host {
+		return websocket.ErrBadWebSocketOrigin
+	}
+	config.Origin = origin
+	return nil
+}
+
+func serveTerminal(ws *websocket.Conn, rec *recorder.Recorder) {
+	defer ws.Close()
+	ws.PayloadType = websocket.BinaryFrame
+
+	input, pipe := io.Pipe()
+	sizes := make(chan tea.WindowSizeMsg, 1)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer pipe.Close()
+
+		for {
+			var msg message
+			if err := websocket.JSON.Receive(ws, &msg); err != nil {
+				return
+			}
+
+			switch msg.Type {
+			case "input":
+				if _, err := pipe.Write([]byte(msg.Data)); err != nil {
+					return
+				}
+			case "resize":
+				if msg.Cols <= 0 || msg.Rows <= 0 {
+					continue
+				}
+				// Keep only the most recent size if the program is behind
+				select {
+				case <-sizes:
+				default:
+				}
+				sizes <- tea.WindowSizeMsg{Width: msg.Cols, Height: msg.Rows}
+			}
+		}
+	}()
+
+	width, height := defaultWidth, defaultHeight
+	select {
+	case size := <-sizes:
+		width, height = size.Width, size.Height
+	case <-time.After(resizeTimeout):
+	}
+
+	tui.RunStream(tui.Stream{
+		Protocol: "websocket",
+		Remote:   ws.Request().RemoteAddr,
+		Term:     "xterm-256color",
+		Width:    width,
+		Height:   height,
+		Input:    input,
+		Output:   &frameWriter{ws: ws},
+		Sizes:    sizes,
+		Done:     done,
+	}, rec)
+}
+
+// frameWriter sends program output to the browser as binary frames, which
+// xterm.js decodes as a UTF-8 stream
+type frameWriter struct {
+	mu sync.Mutex
+	ws *websocket.Conn
+}
+
+func (w *frameWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.ws.Write(p)
+}